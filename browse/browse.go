@@ -0,0 +1,463 @@
+// Package browse is an interactive terminal browser for printtree trees. It is an alternative to
+// piping a large printed tree into a pager: the tree is printed in any TreeStyle, with fold
+// markers, and branches can be expanded and collapsed as they are browsed.
+//
+// Example:
+//    tree.CollapseAll()
+//    if err := browse.New(tree, browse.WithStyle(printtree.ASCIIStyle)).Run(os.Stdin, os.Stdout); err != nil {
+//        log.Fatal(err)
+//    }
+//
+// The keys are
+//    ↑ ↓ k j             move to the previous or next branch
+//    PgUp PgDn Home End  move a page, or to the first or last branch
+//    → l                 expand the branch, or move to its first branch
+//    ← h                 collapse the branch, or move to its parent
+//    Enter Space         expand or collapse the branch
+//    /                   search the labels while typing. Enter ends the search, Esc cancels it
+//    n N                 move to the next or previous match
+//    y c                 copy the path of the branch to the clipboard
+//    q Ctrl-C            quit
+//
+// The terminal is switched to raw mode while browsing (on Unix-like systems), so it does not
+// need any external programs or services.
+package browse
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kevmurray/printtree"
+)
+
+// ANSI escape sequences used to draw the screen
+const (
+	altScreen    = "\x1b[?1049h\x1b[?25l\x1b[?7l" // alternate screen, hidden cursor and no line wrapping
+	normalScreen = "\x1b[?7h\x1b[?25h\x1b[?1049l"
+	home         = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+	reverse      = "\x1b[7m"
+	reset        = "\x1b[0m"
+)
+
+// CopyFunc copies the path of a branch to the clipboard
+type CopyFunc func(path string) error
+
+// Option customizes a Browser
+type Option func(*Browser)
+
+// WithStyle prints the tree in the style. The default is BoxStyle
+func WithStyle(style printtree.TreeStyle) Option {
+	return func(browser *Browser) {
+		browser.style = style
+	}
+}
+
+// WithSeparator joins the labels of the copied paths with the separator. The default is "/"
+func WithSeparator(separator string) Option {
+	return func(browser *Browser) {
+		browser.separator = separator
+	}
+}
+
+// WithCopy copies paths with the function. By default, paths are sent to the terminal with an
+// OSC 52 escape sequence, which most terminal emulators copy to the clipboard
+func WithCopy(copy CopyFunc) Option {
+	return func(browser *Browser) {
+		browser.copy = copy
+	}
+}
+
+// entry is a branch of the tree at one place in the tree. A branch that has been added to more
+// than one tree has an entry for each place
+type entry struct {
+	branch *printtree.Tree
+	parent int // the index of the parent entry, or -1 for the top level branches
+	lines  int // the number of lines in the label
+}
+
+// Browser shows a tree in the terminal and lets the user browse it. Expanding and collapsing
+// branches changes their Collapsed field, so a tree can be browsed again where it was left
+type Browser struct {
+	tree      *printtree.Tree
+	style     printtree.TreeStyle
+	separator string
+	copy      CopyFunc
+
+	entries   []entry // every branch of the tree, parents first
+	cursor    int     // the index of the selected entry
+	top       int     // the first line of the tree on the screen
+	searching bool
+	query     string
+	origin    int    // the entry that was selected when the search started
+	status    string // a message for the status line
+	out       io.Writer
+}
+
+// New returns a browser for the tree
+func New(tree *printtree.Tree, options ...Option) *Browser {
+	browser := &Browser{
+		tree:      tree,
+		style:     printtree.BoxStyle,
+		separator: "/",
+	}
+	for _, option := range options {
+		option(browser)
+	}
+	browser.addEntries(tree, -1, map[*printtree.Tree]bool{tree: true})
+	return browser
+}
+
+// addEntries adds entries for the branches of the tree. Branches that loop back to one of their
+// ancestors are added without their branches
+func (browser *Browser) addEntries(tree *printtree.Tree, parent int, ancestors map[*printtree.Tree]bool) {
+	for _, branch := range tree.Branches {
+		browser.entries = append(browser.entries, entry{
+			branch: branch,
+			parent: parent,
+			lines:  strings.Count(branch.Label, "\n") + 1,
+		})
+		if ancestors[branch] {
+			continue
+		}
+		ancestors[branch] = true
+		browser.addEntries(branch, len(browser.entries)-1, ancestors)
+		delete(ancestors, branch)
+	}
+}
+
+// Run browses the tree until the user quits. Keys are read from in and the screen is written to
+// out. If in is a terminal, it is switched to raw mode until Run returns
+func (browser *Browser) Run(in io.Reader, out io.Writer) error {
+	if file, ok := in.(*os.File); ok && isTerminal(file) {
+		restore, err := makeRaw(file)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	browser.out = out
+	if _, err := io.WriteString(out, altScreen); err != nil {
+		return err
+	}
+	defer io.WriteString(out, normalScreen)
+
+	keys := bufio.NewReader(in)
+	for {
+		width, height := screenSize(in, out)
+		if _, err := io.WriteString(out, browser.render(width, height)); err != nil {
+			return err
+		}
+		key, err := readKey(keys)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !browser.handle(key, height) {
+			return nil
+		}
+	}
+}
+
+// visible returns the indexes of the entries that are not hidden by a collapsed ancestor
+func (browser *Browser) visible() []int {
+	hidden := make([]bool, len(browser.entries))
+	var indexes []int
+	for index, entry := range browser.entries {
+		if entry.parent >= 0 {
+			parent := browser.entries[entry.parent]
+			hidden[index] = hidden[entry.parent] || parent.branch.Collapsed
+		}
+		if !hidden[index] {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+// view returns a copy of the visible part of the tree, for printing
+func (browser *Browser) view(visible []int) *printtree.Tree {
+	tree := printtree.NewTree()
+	copies := make(map[int]*printtree.Tree, len(visible))
+	for _, index := range visible {
+		entry := browser.entries[index]
+		branch := &printtree.Tree{
+			Label:     entry.branch.Label,
+			Collapsed: entry.branch.Collapsed,
+			URL:       entry.branch.URL,
+			Icon:      entry.branch.Icon,
+		}
+		if entry.branch.Collapsed {
+			// the hidden branches are needed for the fold marker and the hidden count
+			branch.Branches = entry.branch.Branches
+		}
+		copies[index] = branch
+		parent := tree
+		if entry.parent >= 0 {
+			parent = copies[entry.parent]
+		}
+		parent.Branches = append(parent.Branches, branch)
+	}
+	return tree
+}
+
+// render returns the escape sequences and text that draw the screen
+func (browser *Browser) render(width, height int) string {
+	visible := browser.visible()
+	// the browser always draws on a terminal, so hyperlinks are printed if it supports them
+	printed := browser.view(visible).PrintStyle(browser.style, printtree.WithFoldMarkers(), printtree.WithHiddenCount(),
+		printtree.WithHyperlinks(printtree.HyperlinksAuto))
+	lines := strings.Split(strings.TrimSuffix(printed, "\n"), "\n")
+
+	// find the lines of the selected entry and scroll them into view
+	first, last := 0, 0
+	for _, index := range visible {
+		last = first + browser.entries[index].lines
+		if index == browser.cursor {
+			break
+		}
+		first = last
+	}
+	rows := height - 1
+	if rows < 1 {
+		rows = 1
+	}
+	if first < browser.top {
+		browser.top = first
+	}
+	if last > browser.top+rows {
+		browser.top = last - rows
+	}
+
+	screen := &strings.Builder{}
+	screen.WriteString(home)
+	for row := 0; row < rows; row++ {
+		number := browser.top + row
+		if number < len(lines) {
+			line := lines[number]
+			if number >= first && number < last {
+				line = highlight(line, browser.entries[browser.cursor].branch.Label, number-first)
+			}
+			screen.WriteString(line)
+		}
+		screen.WriteString(clearLine + "\r\n")
+	}
+	screen.WriteString(browser.statusLine(width))
+	screen.WriteString(clearLine + clearBelow)
+	return screen.String()
+}
+
+// highlight shows the label in the printed line in reverse video
+func highlight(line, label string, lineIndex int) string {
+	text := strings.Split(label, "\n")[lineIndex]
+	at := strings.LastIndex(line, text)
+	if text == "" || at < 0 {
+		return reverse + line + reset
+	}
+	return line[:at] + reverse + text + reset + line[at+len(text):]
+}
+
+// statusLine returns the last line of the screen: the search, a message or the selected path
+func (browser *Browser) statusLine(width int) string {
+	status := browser.status
+	switch {
+	case browser.searching && status != "":
+		status = "/" + browser.query + "  " + status
+	case browser.searching:
+		status = "/" + browser.query
+	case status == "" && len(browser.entries) > 0:
+		status = browser.path(browser.cursor)
+	}
+	if runes := []rune(status); len(runes) > width && width > 0 {
+		status = string(runes[:width])
+	}
+	return status
+}
+
+// path returns the labels of the entry and its ancestors joined with the separator
+func (browser *Browser) path(index int) string {
+	var labels []string
+	for ; index >= 0; index = browser.entries[index].parent {
+		labels = append([]string{browser.entries[index].branch.Label}, labels...)
+	}
+	return strings.Join(labels, browser.separator)
+}
+
+// handle updates the browser for the key. Returns false if the user quit
+func (browser *Browser) handle(key key, height int) bool {
+	browser.status = ""
+	if len(browser.entries) == 0 {
+		return key.code != keyQuit && !(key.code == keyRune && key.r == 'q')
+	}
+	if browser.searching {
+		browser.handleSearch(key)
+		return true
+	}
+
+	visible := browser.visible()
+	position := indexOf(visible, browser.cursor)
+	for position < 0 {
+		// the selected entry was hidden by collapsing a branch that appears more than once
+		browser.cursor = browser.entries[browser.cursor].parent
+		position = indexOf(visible, browser.cursor)
+	}
+	selected := browser.entries[browser.cursor].branch
+	switch key.code {
+	case keyQuit:
+		return false
+	case keyUp:
+		browser.moveTo(visible, position-1)
+	case keyDown:
+		browser.moveTo(visible, position+1)
+	case keyPageUp:
+		browser.moveTo(visible, position-(height-1))
+	case keyPageDown:
+		browser.moveTo(visible, position+(height-1))
+	case keyHome:
+		browser.moveTo(visible, 0)
+	case keyEnd:
+		browser.moveTo(visible, len(visible)-1)
+	case keyRight:
+		browser.expand(selected, visible, position)
+	case keyLeft:
+		browser.collapse(selected)
+	case keyEnter:
+		selected.Collapsed = !selected.Collapsed && len(selected.Branches) > 0
+	case keyRune:
+		switch key.r {
+		case 'q':
+			return false
+		case 'k':
+			browser.moveTo(visible, position-1)
+		case 'j':
+			browser.moveTo(visible, position+1)
+		case 'l':
+			browser.expand(selected, visible, position)
+		case 'h':
+			browser.collapse(selected)
+		case ' ':
+			selected.Collapsed = !selected.Collapsed && len(selected.Branches) > 0
+		case '/':
+			browser.searching, browser.query, browser.origin = true, "", browser.cursor
+		case 'n':
+			browser.search(browser.cursor+1, 1)
+		case 'N':
+			browser.search(browser.cursor-1, -1)
+		case 'y', 'c':
+			browser.copyPath()
+		}
+	}
+	return true
+}
+
+// handleSearch updates the search for the key
+func (browser *Browser) handleSearch(key key) {
+	switch key.code {
+	case keyEscape, keyQuit:
+		browser.searching = false
+		browser.cursor = browser.origin
+	case keyEnter:
+		browser.searching = false
+	case keyBackspace:
+		if runes := []rune(browser.query); len(runes) > 0 {
+			browser.query = string(runes[:len(runes)-1])
+			browser.search(browser.origin, 1)
+		}
+	case keyRune:
+		browser.query += string(key.r)
+		browser.search(browser.origin, 1)
+	}
+}
+
+// moveTo selects the visible entry at the position, within the bounds of the visible entries
+func (browser *Browser) moveTo(visible []int, position int) {
+	if position >= len(visible) {
+		position = len(visible) - 1
+	}
+	if position < 0 {
+		position = 0
+	}
+	browser.cursor = visible[position]
+}
+
+// expand expands the selected branch, or selects its first branch if it is already expanded
+func (browser *Browser) expand(selected *printtree.Tree, visible []int, position int) {
+	if selected.Collapsed {
+		selected.Collapsed = false
+		return
+	}
+	if position+1 < len(visible) && browser.entries[visible[position+1]].parent == browser.cursor {
+		browser.cursor = visible[position+1]
+	}
+}
+
+// collapse collapses the selected branch, or selects its parent if it is already collapsed or
+// has no branches
+func (browser *Browser) collapse(selected *printtree.Tree) {
+	if !selected.Collapsed && len(selected.Branches) > 0 {
+		selected.Collapsed = true
+		return
+	}
+	if parent := browser.entries[browser.cursor].parent; parent >= 0 {
+		browser.cursor = parent
+	}
+}
+
+// search selects the first entry from the start, in the direction, with a label that contains
+// the query, ignoring case, and expands its ancestors. The search wraps around the ends of the
+// tree
+func (browser *Browser) search(start, direction int) {
+	if browser.query == "" {
+		browser.cursor = browser.origin
+		return
+	}
+	query := strings.ToLower(browser.query)
+	count := len(browser.entries)
+	for step := 0; step < count; step++ {
+		index := ((start+step*direction)%count + count) % count
+		if !strings.Contains(strings.ToLower(browser.entries[index].branch.Label), query) {
+			continue
+		}
+		browser.cursor = index
+		for parent := browser.entries[index].parent; parent >= 0; parent = browser.entries[parent].parent {
+			browser.entries[parent].branch.Collapsed = false
+		}
+		return
+	}
+	browser.status = "no match"
+}
+
+// copyPath copies the path of the selected entry
+func (browser *Browser) copyPath() {
+	path := browser.path(browser.cursor)
+	var err error
+	if browser.copy != nil {
+		err = browser.copy(path)
+	} else {
+		// OSC 52 sets the clipboard of the terminal
+		_, err = fmt.Fprintf(browser.out, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(path)))
+	}
+	if err != nil {
+		browser.status = fmt.Sprintf("copy failed: %v", err)
+		return
+	}
+	browser.status = "copied " + path
+}
+
+// indexOf returns the position of the value in the list, or -1 if it is not there
+func indexOf(list []int, value int) int {
+	for position := range list {
+		if list[position] == value {
+			return position
+		}
+	}
+	return -1
+}
@@ -0,0 +1,208 @@
+package browse
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kevmurray/printtree"
+	"github.com/stretchr/testify/assert"
+)
+
+// screen returns the screen drawn by the browser without escape sequences, one string per line
+func screen(browser *Browser, width, height int) []string {
+	text := browser.render(width, height)
+	for _, sequence := range []string{home, clearLine, clearBelow, reverse, reset} {
+		text = strings.ReplaceAll(text, sequence, "")
+	}
+	return strings.Split(text, "\r\n")
+}
+
+// press handles each of the keys in turn
+func press(browser *Browser, keys ...key) {
+	for _, key := range keys {
+		browser.handle(key, 5)
+	}
+}
+
+// runes returns the keys that type the text
+func runes(text string) []key {
+	var keys []key
+	for _, r := range text {
+		keys = append(keys, key{code: keyRune, r: r})
+	}
+	return keys
+}
+
+func TestBrowser_Render(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.CollapseAll()
+
+	browser := New(tree)
+	assert.Equal(t, []string{
+		"▸ src (4 hidden)",
+		"  README.md",
+		"",
+		"",
+		"src",
+	}, screen(browser, 40, 5))
+
+	// the selected label is highlighted
+	assert.Contains(t, browser.render(40, 5), "▸ "+reverse+"src"+reset+" (4 hidden)")
+}
+
+func TestBrowser_Navigate(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.CollapseAll()
+	browser := New(tree)
+
+	press(browser, key{code: keyRight}, key{code: keyRight}, key{code: keyDown})
+	assert.False(t, src.Collapsed)
+	assert.Equal(t, []string{
+		"▾ src",
+		"├──   main.go",
+		"╰── ▸ util (2 hidden)",
+		"  README.md",
+		"src/util",
+	}, screen(browser, 40, 5))
+
+	// left moves to the parent, then collapses it
+	press(browser, key{code: keyEnter}, key{code: keyDown}, key{code: keyLeft})
+	assert.Equal(t, "src/util", browser.path(browser.cursor))
+	assert.False(t, util.Collapsed)
+	press(browser, key{code: keyLeft})
+	assert.True(t, util.Collapsed)
+
+	press(browser, key{code: keyEnd})
+	assert.Equal(t, "README.md", browser.path(browser.cursor))
+	press(browser, runes("kk")...)
+	assert.Equal(t, "src/main.go", browser.path(browser.cursor))
+	press(browser, key{code: keyHome}, key{code: keyPageDown})
+	assert.Equal(t, "README.md", browser.path(browser.cursor))
+}
+
+func TestBrowser_Scroll(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	browser := New(tree)
+
+	press(browser, key{code: keyEnd})
+	assert.Equal(t, []string{
+		"    ├──   a.go",
+		"    ╰──   b.go",
+		"  README.md",
+		"README.md",
+	}, screen(browser, 40, 4)[0:4])
+}
+
+func TestBrowser_Search(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.CollapseAll()
+	browser := New(tree)
+
+	press(browser, runes("/B.G")...)
+	assert.Equal(t, "src/util/b.go", browser.path(browser.cursor))
+	assert.False(t, util.Collapsed, "the ancestors of matches are expanded")
+	assert.Equal(t, "/B.G", screen(browser, 40, 5)[4])
+
+	// the search starts again from where it started
+	press(browser, key{code: keyBackspace}, key{code: keyBackspace}, key{code: keyBackspace})
+	assert.Equal(t, "src", browser.path(browser.cursor))
+	press(browser, runes(".go")...)
+	press(browser, key{code: keyEnter})
+	assert.Equal(t, "src/main.go", browser.path(browser.cursor))
+
+	// next and previous matches wrap around
+	press(browser, runes("nn")...)
+	assert.Equal(t, "src/util/b.go", browser.path(browser.cursor))
+	press(browser, runes("n")...)
+	assert.Equal(t, "src/main.go", browser.path(browser.cursor))
+	press(browser, runes("N")...)
+	assert.Equal(t, "src/util/b.go", browser.path(browser.cursor))
+
+	press(browser, runes("/xyz")...)
+	assert.Equal(t, "/xyz  no match", screen(browser, 40, 5)[4])
+	press(browser, key{code: keyEscape})
+	assert.Equal(t, "src/util/b.go", browser.path(browser.cursor))
+}
+
+func TestBrowser_Copy(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.CollapseAll()
+
+	var copied string
+	browser := New(tree, WithSeparator("\\"), WithCopy(func(path string) error {
+		copied = path
+		return nil
+	}))
+	press(browser, key{code: keyRight}, key{code: keyDown}, key{code: keyRune, r: 'y'})
+	assert.Equal(t, `src\main.go`, copied)
+	assert.Equal(t, `copied src\main.go`, screen(browser, 40, 5)[4])
+
+	browser = New(tree, WithCopy(func(path string) error {
+		return errors.New("no clipboard")
+	}))
+	press(browser, key{code: keyRune, r: 'c'})
+	assert.Equal(t, "copy failed: no clipboard", screen(browser, 40, 5)[4])
+}
+
+func TestBrowser_Run(t *testing.T) {
+	tree := printtree.NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.CollapseAll()
+
+	out := &bytes.Buffer{}
+	err := New(tree, WithStyle(printtree.ASCIIStyle)).Run(strings.NewReader("l\x1b[Byq"), out)
+	assert.NoError(t, err)
+
+	output := out.String()
+	assert.True(t, strings.HasPrefix(output, altScreen))
+	assert.True(t, strings.HasSuffix(output, normalScreen))
+	assert.Contains(t, output, "|--   "+reverse+"main.go"+reset)
+	// the path is copied with an OSC 52 escape sequence
+	assert.Contains(t, output, "\x1b]52;c;c3JjL21haW4uZ28=\a")
+}
+
+func TestBrowser_Cycle(t *testing.T) {
+	tree := printtree.NewTree()
+	a := tree.AddBranch("a")
+	a.Branches = append(a.Branches, a)
+	browser := New(tree)
+	assert.Len(t, browser.entries, 2)
+	assert.Equal(t, []string{
+		"▾ a",
+		"╰──   a",
+		"a",
+	}, screen(browser, 40, 3))
+}
+
+func TestBrowser_Empty(t *testing.T) {
+	browser := New(printtree.NewTree())
+	assert.Equal(t, []string{"", ""}, screen(browser, 40, 2))
+	assert.False(t, browser.handle(key{code: keyRune, r: 'q'}, 2))
+}
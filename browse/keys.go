@@ -0,0 +1,102 @@
+package browse
+
+import (
+	"bufio"
+	"unicode/utf8"
+)
+
+// keyCode identifies the keys that the browser handles
+type keyCode int
+
+const (
+	keyRune keyCode = iota // a printable character
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyBackspace
+	keyEscape
+	keyQuit // Ctrl-C
+	keyUnknown
+)
+
+// key is a key that was pressed
+type key struct {
+	code keyCode
+	r    rune // the character, for keyRune
+}
+
+// escapeKeys are the keys sent as escape sequences, without the leading "\x1b[" or "\x1bO"
+var escapeKeys = map[string]keyCode{
+	"A":  keyUp,
+	"B":  keyDown,
+	"C":  keyRight,
+	"D":  keyLeft,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"1~": keyHome,
+	"4~": keyEnd,
+	"7~": keyHome,
+	"8~": keyEnd,
+	"5~": keyPageUp,
+	"6~": keyPageDown,
+}
+
+// readKey reads the next key from the terminal input
+func readKey(in *bufio.Reader) (key, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	switch r {
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case 0x7f, '\b':
+		return key{code: keyBackspace}, nil
+	case 0x03:
+		return key{code: keyQuit}, nil
+	case 0x1b:
+		return readEscape(in)
+	}
+	if r < ' ' || r == utf8.RuneError {
+		return key{code: keyUnknown}, nil
+	}
+	return key{code: keyRune, r: r}, nil
+}
+
+// readEscape reads the rest of an escape sequence. An escape that is not followed by more input
+// straight away is the escape key itself
+func readEscape(in *bufio.Reader) (key, error) {
+	if in.Buffered() == 0 {
+		return key{code: keyEscape}, nil
+	}
+	introducer, err := in.ReadByte()
+	if err != nil {
+		return key{}, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return key{code: keyUnknown}, nil
+	}
+
+	// the sequence ends with a letter or a tilde
+	sequence := ""
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return key{}, err
+		}
+		sequence += string(b)
+		if b == '~' || (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') {
+			break
+		}
+	}
+	if code, ok := escapeKeys[sequence]; ok {
+		return key{code: code}, nil
+	}
+	return key{code: keyUnknown}, nil
+}
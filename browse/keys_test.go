@@ -0,0 +1,41 @@
+package browse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadKey(t *testing.T) {
+	in := bufio.NewReader(strings.NewReader("j\x1b[A\x1bOB\x1b[5~\x1b[F\r\x7f\x03é\x1b[99~\x01"))
+	expected := []key{
+		{code: keyRune, r: 'j'},
+		{code: keyUp},
+		{code: keyDown},
+		{code: keyPageUp},
+		{code: keyEnd},
+		{code: keyEnter},
+		{code: keyBackspace},
+		{code: keyQuit},
+		{code: keyRune, r: 'é'},
+		{code: keyUnknown},
+		{code: keyUnknown},
+	}
+	for _, want := range expected {
+		got, err := readKey(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := readKey(in)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReadKey_Escape(t *testing.T) {
+	// an escape without anything after it is the escape key
+	got, err := readKey(bufio.NewReader(strings.NewReader("\x1b")))
+	assert.NoError(t, err)
+	assert.Equal(t, key{code: keyEscape}, got)
+}
@@ -0,0 +1,9 @@
+package browse
+
+import "syscall"
+
+// the ioctl requests that get and set the terminal attributes
+const (
+	getTermios = syscall.TCGETS
+	setTermios = syscall.TCSETS
+)
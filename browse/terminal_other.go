@@ -0,0 +1,26 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package browse
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// isTerminal returns true if the file is a terminal. Raw mode is not supported on this system,
+// so the terminal is treated like any other input
+func isTerminal(file *os.File) bool {
+	return false
+}
+
+// makeRaw is not supported on this system
+func makeRaw(file *os.File) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported")
+}
+
+// screenSize returns the default size of 80x24
+func screenSize(in io.Reader, out io.Writer) (int, int) {
+	return 80, 24
+}
@@ -0,0 +1,68 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package browse
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the size of the terminal, as returned by the TIOCGWINSZ ioctl
+type winsize struct {
+	rows, cols, x, y uint16
+}
+
+// ioctl calls the ioctl system call on the file descriptor
+func ioctl(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// isTerminal returns true if the file is a terminal
+func isTerminal(file *os.File) bool {
+	var termios syscall.Termios
+	return ioctl(file.Fd(), getTermios, unsafe.Pointer(&termios)) == nil
+}
+
+// makeRaw switches the terminal to raw mode, where keys are read one at a time without being
+// echoed. Returns a function that restores the previous mode
+func makeRaw(file *os.File) (func(), error) {
+	var saved syscall.Termios
+	if err := ioctl(file.Fd(), getTermios, unsafe.Pointer(&saved)); err != nil {
+		return nil, err
+	}
+
+	raw := saved
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(file.Fd(), setTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return func() {
+		_ = ioctl(file.Fd(), setTermios, unsafe.Pointer(&saved))
+	}, nil
+}
+
+// screenSize returns the size of the terminal that is either the input or the output, or 80x24
+// if neither is a terminal
+func screenSize(in io.Reader, out io.Writer) (int, int) {
+	for _, f := range []interface{}{out, in} {
+		if file, ok := f.(*os.File); ok {
+			var size winsize
+			if ioctl(file.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&size)) == nil && size.cols > 0 && size.rows > 0 {
+				return int(size.cols), int(size.rows)
+			}
+		}
+	}
+	return 80, 24
+}
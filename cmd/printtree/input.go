@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kevmurray/printtree"
+)
+
+// jsonBranch is a branch of a tree in JSON
+type jsonBranch struct {
+	Label    string        `json:"label"`
+	URL      string        `json:"url,omitempty"`
+	Icon     string        `json:"icon,omitempty"`
+	Branches []*jsonBranch `json:"branches,omitempty"`
+}
+
+// readTree reads a tree in the input format from the reader and adds its branches to the tree
+func readTree(tree *printtree.Tree, r io.Reader, opts options) error {
+	input, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	format := opts.input
+	if format == "auto" {
+		format = detectFormat(input, opts.separator)
+	}
+	switch format {
+	case "indent":
+		readIndented(tree, input, indentColumn)
+	case "tree":
+		readIndented(tree, input, scaffoldColumn)
+	case "paths":
+		readPaths(tree, input, opts.separator)
+	case "json":
+		return readJSON(tree, input)
+	default:
+		return fmt.Errorf("unknown input format %q", format)
+	}
+	return nil
+}
+
+// detectFormat guesses the format of the input
+func detectFormat(input []byte, separator string) string {
+	trimmed := bytes.TrimSpace(input)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return "json"
+	}
+
+	indented := false
+	paths := false
+	for _, line := range lines(input) {
+		if _, label := scaffoldColumn(line); label != strings.TrimSpace(line) {
+			return "tree"
+		}
+		if strings.TrimLeftFunc(line, unicode.IsSpace) != line {
+			indented = true
+		}
+		if strings.Contains(line, separator) {
+			paths = true
+		}
+	}
+	if paths && !indented {
+		return "paths"
+	}
+	return "indent"
+}
+
+// readIndented reads a tree where each label is on its own line, indented further than its
+// parent. The column function returns the column where a line's label starts, and the label
+func readIndented(tree *printtree.Tree, input []byte, column func(line string) (int, string)) {
+	type level struct {
+		column int
+		branch *printtree.Tree
+	}
+	stack := []level{{column: -1, branch: tree}}
+
+	for _, line := range lines(input) {
+		labelColumn, label := column(line)
+		for stack[len(stack)-1].column >= labelColumn {
+			stack = stack[:len(stack)-1]
+		}
+		branch := stack[len(stack)-1].branch.AddBranch(label)
+		stack = append(stack, level{column: labelColumn, branch: branch})
+	}
+}
+
+// indentColumn returns the column where the label on an indented line starts, and the label.
+// Tabs are counted as 8 columns
+func indentColumn(line string) (int, string) {
+	column := 0
+	for index, r := range line {
+		switch r {
+		case ' ':
+			column++
+		case '\t':
+			column += 8 - column%8
+		default:
+			return column, strings.TrimRightFunc(line[index:], unicode.IsSpace)
+		}
+	}
+	return column, ""
+}
+
+// branchGlyphs start the scaffold of a branch in a printed tree, and lineGlyphs are the
+// horizontal lines that may follow them
+const (
+	branchGlyphs = "├╰└┣┗|'`+"
+	verticals    = "│┃|"
+	lineGlyphs   = "─━-"
+)
+
+// scaffoldColumn returns the column where the label on a line of a printed tree starts, after
+// the scaffold, and the label
+func scaffoldColumn(line string) (int, string) {
+	column := 0
+	rest := line
+	for len(rest) > 0 {
+		r, size := utf8.DecodeRuneInString(rest)
+		after := rest[size:]
+		if strings.ContainsRune(branchGlyphs, r) {
+			// a branch glyph followed by a line (or, for narrow box styles, a space) ends the
+			// scaffold
+			lineEnd := len(after) - len(strings.TrimLeft(after, lineGlyphs))
+			if lineEnd > 0 || (r > unicode.MaxASCII && strings.HasPrefix(after, " ")) {
+				column += 1 + utf8.RuneCountInString(after[:lineEnd])
+				label := after[lineEnd:]
+				if strings.HasPrefix(label, " ") {
+					label = label[1:]
+					column++
+				}
+				return column, strings.TrimRightFunc(label, unicode.IsSpace)
+			}
+		}
+		if r != ' ' && !strings.ContainsRune(verticals, r) {
+			break
+		}
+		column++
+		rest = after
+	}
+	return column, strings.TrimRightFunc(rest, unicode.IsSpace)
+}
+
+// readPaths reads a tree from a list of paths, one per line. Paths with the same leading labels
+// share the same branches
+func readPaths(tree *printtree.Tree, input []byte, separator string) {
+	for _, line := range lines(input) {
+		line = strings.Trim(strings.TrimSpace(line), separator)
+		if line == "" {
+			continue
+		}
+		branch := tree
+		for _, label := range strings.Split(line, separator) {
+			if label == "" || label == "." {
+				continue
+			}
+			branch = child(branch, label)
+		}
+	}
+}
+
+// child returns the branch of the tree with the label, adding it if there is none
+func child(tree *printtree.Tree, label string) *printtree.Tree {
+	for _, branch := range tree.Branches {
+		if branch.Label == label {
+			return branch
+		}
+	}
+	return tree.AddBranch(label)
+}
+
+// readJSON reads a tree from JSON, which is either a single branch or a list of branches
+func readJSON(tree *printtree.Tree, input []byte) error {
+	var branches []*jsonBranch
+	if trimmed := bytes.TrimSpace(input); len(trimmed) > 0 && trimmed[0] == '{' {
+		branch := &jsonBranch{}
+		if err := json.Unmarshal(input, branch); err != nil {
+			return err
+		}
+		if branch.Label == "" {
+			// an unlabeled branch is a root
+			branches = branch.Branches
+		} else {
+			branches = []*jsonBranch{branch}
+		}
+	} else if err := json.Unmarshal(input, &branches); err != nil {
+		return err
+	}
+
+	for _, branch := range branches {
+		branch.addTo(tree)
+	}
+	return nil
+}
+
+// addTo adds the branch, with all its branches, to the tree
+func (branch *jsonBranch) addTo(tree *printtree.Tree) {
+	added := tree.AddBranch(branch.Label)
+	added.URL = branch.URL
+	added.Icon = branch.Icon
+	for _, child := range branch.Branches {
+		child.addTo(added)
+	}
+}
+
+// lines returns the lines of the input that are not blank
+func lines(input []byte) []string {
+	var result []string
+	for _, line := range strings.Split(string(input), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/kevmurray/printtree"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, "json", detectFormat([]byte(` [{"label": "a"}]`), "/"))
+	assert.Equal(t, "json", detectFormat([]byte(`{"label": "a"}`), "/"))
+	assert.Equal(t, "tree", detectFormat([]byte("a\n╰── b\n"), "/"))
+	assert.Equal(t, "tree", detectFormat([]byte("a\n'-- b/c\n"), "/"))
+	assert.Equal(t, "paths", detectFormat([]byte("a\na/b\n"), "/"))
+	assert.Equal(t, "indent", detectFormat([]byte("a\n  a/b\n"), "/"))
+	assert.Equal(t, "indent", detectFormat([]byte("a\n  b\n- c\n"), "/"))
+}
+
+func TestReadIndented(t *testing.T) {
+	tree := printtree.NewTree()
+	readIndented(tree, []byte("a\n  b\n    c\n\n  d\n\te\nf\n"), indentColumn)
+	assert.Equal(t, `a
+├── b
+│   ╰── c
+╰── d
+    ╰── e
+f
+`, tree.Print())
+}
+
+func TestReadTree(t *testing.T) {
+	tree := printtree.NewTree()
+	readIndented(tree, []byte(`root
+├── a
+│   ├── - dash
+│   ╰── + plus
+│       ╰── deep
+╰── b ── c
+`), scaffoldColumn)
+	assert.Equal(t, `root
+├── a
+│   ├── - dash
+│   ╰── + plus
+│       ╰── deep
+╰── b ── c
+`, tree.Print())
+}
+
+func TestScaffoldColumn(t *testing.T) {
+	for line, expected := range map[string]struct {
+		column int
+		label  string
+	}{
+		"root":         {0, "root"},
+		"├── a":        {4, "a"},
+		"│   ╰── b  ":  {8, "b"},
+		"|   '-- c":    {8, "c"},
+		"|-d":          {2, "d"},
+		"┃ ┗ e":        {4, "e"},
+		"    ╰ f":      {6, "f"},
+		"- not a tree": {0, "- not a tree"},
+		"+ not a tree": {0, "+ not a tree"},
+		"|-- - a dash": {4, "- a dash"},
+	} {
+		column, label := scaffoldColumn(line)
+		assert.Equal(t, expected.column, column, line)
+		assert.Equal(t, expected.label, label, line)
+	}
+}
+
+func TestReadPaths(t *testing.T) {
+	tree := printtree.NewTree()
+	readPaths(tree, []byte("/usr/bin/\n./usr/lib\r\n\nusr/bin/go\n"), "/")
+	assert.Equal(t, `usr
+├── bin
+│   ╰── go
+╰── lib
+`, tree.Print())
+}
+
+func TestReadJSON(t *testing.T) {
+	tree := printtree.NewTree()
+	assert.NoError(t, readJSON(tree, []byte(`{"label": "a", "branches": [{"label": "b"}]}`)))
+	assert.NoError(t, readJSON(tree, []byte(`{"branches": [{"label": "c"}]}`)))
+	assert.NoError(t, readJSON(tree, []byte(`[{"label": "d"}]`)))
+	assert.Error(t, readJSON(tree, []byte(`{"label": `)))
+	assert.Equal(t, `a
+╰── b
+c
+d
+`, tree.Print())
+}
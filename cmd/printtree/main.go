@@ -0,0 +1,232 @@
+// Command printtree reads a tree from files or standard input and prints it in any of the styles
+// of the printtree package.
+//
+// Usage:
+//   printtree [flags] [file ...]
+//
+// The input can be indented text (one label per line, indented under its parent), a list of
+// paths (one per line, such as the output of find), JSON ({"label": ..., "branches": [...]})
+// or a tree that has already been printed. By default, the format is guessed from the input.
+//
+// For example
+//   find . -name '*.go' | printtree -style ascii -sort natural
+//   printtree -input json -depth 2 -filter '\.go$' tree.json
+//
+// With -browse, the tree is shown in an interactive browser (see package browse) instead.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kevmurray/printtree"
+	"github.com/kevmurray/printtree/browse"
+)
+
+// sorts are the orders that branches can be sorted in
+var sorts = map[string]printtree.BranchLess{
+	"label":       printtree.LabelLess,
+	"natural":     printtree.NaturalLess,
+	"fold":        printtree.FoldLess,
+	"size":        printtree.SizeLess,
+	"depth":       printtree.DepthLess,
+	"dirs-first":  printtree.Chain(printtree.LeavesLast, printtree.NaturalLess),
+	"files-first": printtree.Chain(printtree.LeavesFirst, printtree.NaturalLess),
+}
+
+// layouts are the names of the layouts
+var layouts = map[string]printtree.Layout{
+	"indented":      printtree.IndentedLayout,
+	"top-down":      printtree.TopDownLayout,
+	"left-to-right": printtree.LeftToRightLayout,
+}
+
+// hyperlinks are the names of the hyperlink modes
+var hyperlinks = map[string]printtree.Hyperlinks{
+	"auto": printtree.HyperlinksAuto,
+	"on":   printtree.HyperlinksOn,
+	"off":  printtree.HyperlinksOff,
+}
+
+// options are the command line flags
+type options struct {
+	input     string
+	output    string
+	style     string
+	styleFile string
+	layout    string
+	separator string
+	depth     int
+	sort      string
+	reverse   bool
+	root      bool
+	filter    string
+	browse    bool
+	links     string
+	icons     bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the arguments and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts := options{}
+	flags := flag.NewFlagSet("printtree", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.input, "input", "auto", "input format: auto, indent, paths, json or tree")
+	flags.StringVar(&opts.output, "output", "text", "output format: text, indent, paths or json")
+	flags.StringVar(&opts.style, "style", "box", "style of the printed tree: "+strings.Join(printtree.StyleNames(), ", "))
+	flags.StringVar(&opts.styleFile, "style-file", "", "load more styles from this file (see printtree.LoadStyles)")
+	flags.StringVar(&opts.layout, "layout", "indented", "layout of the printed tree: "+names(layouts))
+	flags.StringVar(&opts.separator, "separator", "/", "separator of the labels in paths")
+	flags.IntVar(&opts.depth, "depth", 0, "print only this many levels of branches (0 for all)")
+	flags.StringVar(&opts.sort, "sort", "", "sort the branches: "+names(sorts))
+	flags.BoolVar(&opts.reverse, "reverse", false, "reverse the sort order")
+	flags.BoolVar(&opts.root, "root", false, "print a root line (\".\") that the top level branches hang off")
+	flags.StringVar(&opts.filter, "filter", "", "print only branches with labels matching this regular expression, and their parents")
+	flags.StringVar(&opts.links, "hyperlinks", "auto", "print the urls of JSON branches as terminal hyperlinks: "+names(hyperlinks))
+	flags.BoolVar(&opts.icons, "icons", false, "print file type icons in front of the labels")
+	flags.BoolVar(&opts.browse, "browse", false, "browse the tree interactively in the terminal instead of printing it")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: printtree [flags] [file ...]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if err := printTrees(opts, flags.Args(), stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "printtree: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printTrees reads the trees from the files (or stdin if there are none) and prints them
+func printTrees(opts options, files []string, stdin io.Reader, stdout io.Writer) error {
+	if opts.styleFile != "" {
+		spec, err := ioutil.ReadFile(opts.styleFile)
+		if err != nil {
+			return err
+		}
+		if _, err := printtree.LoadStyles(spec); err != nil {
+			return fmt.Errorf("%s: %w", opts.styleFile, err)
+		}
+	}
+
+	tree := printtree.NewTree()
+	if len(files) == 0 {
+		if err := readTree(tree, stdin, opts); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		if err := readFile(tree, file, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.filter != "" {
+		pattern, err := regexp.Compile(opts.filter)
+		if err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+		filter(tree, pattern)
+	}
+	if opts.depth > 0 {
+		prune(tree, opts.depth)
+	}
+	if opts.sort != "" {
+		less, ok := sorts[opts.sort]
+		if !ok {
+			return fmt.Errorf("unknown sort %q", opts.sort)
+		}
+		if opts.reverse {
+			less = printtree.Reverse(less)
+		}
+		tree.DeepSortCustom(less)
+	}
+
+	if opts.browse {
+		return browseTree(tree, opts)
+	}
+	return writeTree(stdout, tree, opts)
+}
+
+// browseTree shows the tree in the interactive browser. The keys are read from the terminal,
+// since the standard input may be the tree itself
+func browseTree(tree *printtree.Tree, opts options) error {
+	style, err := printtree.StyleByName(opts.style)
+	if err != nil {
+		return err
+	}
+	terminal, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("browse needs a terminal: %w", err)
+	}
+	defer terminal.Close()
+
+	tree.CollapseAll()
+	return browse.New(tree, browse.WithStyle(style), browse.WithSeparator(opts.separator)).Run(terminal, terminal)
+}
+
+// readFile reads the tree in the file and adds its branches to the tree
+func readFile(tree *printtree.Tree, name string, opts options) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := readTree(tree, file, opts); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// filter removes the branches whose labels do not match the pattern and that have no branches
+// that match. Returns true if any branch of the tree matched
+func filter(tree *printtree.Tree, pattern *regexp.Regexp) bool {
+	kept := tree.Branches[:0]
+	for _, branch := range tree.Branches {
+		if filter(branch, pattern) || pattern.MatchString(branch.Label) {
+			kept = append(kept, branch)
+		}
+	}
+	tree.Branches = kept
+	return len(kept) > 0
+}
+
+// prune removes the branches that are more than depth levels below the tree
+func prune(tree *printtree.Tree, depth int) {
+	if depth == 0 {
+		tree.Branches = nil
+		return
+	}
+	for _, branch := range tree.Branches {
+		prune(branch, depth-1)
+	}
+}
+
+// names returns the sorted keys of a map with string keys, for the help text
+func names(m interface{}) string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
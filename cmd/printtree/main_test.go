@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pathsInput = `src/main.go
+src/util/a.go
+src/util/b.go
+README.md
+docs/file10.md
+docs/file2.md
+`
+
+// runWith runs the command with the input and returns the exit code, output and errors
+func runWith(input string, args ...string) (int, string, string) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	code := run(args, strings.NewReader(input), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	code, output, _ := runWith(pathsInput)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, `src
+├── main.go
+╰── util
+    ├── a.go
+    ╰── b.go
+README.md
+docs
+├── file10.md
+╰── file2.md
+`, output)
+}
+
+func TestRun_Flags(t *testing.T) {
+	_, output, _ := runWith(pathsInput, "-style", "ascii", "-sort", "natural", "-depth", "2")
+	assert.Equal(t, `README.md
+docs
+|-- file2.md
+'-- file10.md
+src
+|-- main.go
+'-- util
+`, output)
+
+	_, output, _ = runWith(pathsInput, "-sort", "dirs-first", "-filter", `b\.go|README`)
+	assert.Equal(t, `src
+╰── util
+    ╰── b.go
+README.md
+`, output)
+
+	_, output, _ = runWith(pathsInput, "-output", "paths", "-depth", "1", "-sort", "label", "-reverse")
+	assert.Equal(t, `src
+docs
+README.md
+`, output)
+
+	_, output, _ = runWith("java::util\njava::io\n", "-separator", "::", "-output", "paths")
+	assert.Equal(t, `java
+java::util
+java::io
+`, output)
+}
+
+func TestRun_RoundTrip(t *testing.T) {
+	// every output format can be read back in
+	_, expected, _ := runWith(pathsInput)
+	for _, format := range []string{"text", "indent", "paths", "json"} {
+		_, output, _ := runWith(pathsInput, "-output", format)
+		_, printed, _ := runWith(output)
+		assert.Equal(t, expected, printed, format)
+	}
+	for _, style := range []string{"ascii", "box", "box-bold", "ascii-narrow", "box-narrow", "box-bold-narrow"} {
+		_, output, _ := runWith(pathsInput, "-style", style)
+		_, printed, _ := runWith(output)
+		assert.Equal(t, expected, printed, style)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	code, _, errors := runWith(pathsInput, "-style", "fancy")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "printtree: unknown style \"fancy\"\n", errors)
+
+	code, _, errors = runWith(pathsInput, "-filter", "(")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errors, "invalid filter")
+
+	code, _, _ = runWith(pathsInput, "-input", "yaml")
+	assert.Equal(t, 1, code)
+
+	code, _, _ = runWith("", "does-not-exist.txt")
+	assert.Equal(t, 1, code)
+
+	code, _, _ = runWith("", "-no-such-flag")
+	assert.Equal(t, 2, code)
+}
+
+// loadStyleFile loads testdata/styles.txt only in the first run of TestRun_StyleFile. Its style
+// is registered as "arrows" for the rest of the process, and a name can not be loaded twice
+var loadStyleFile sync.Once
+
+func TestRun_StyleFile(t *testing.T) {
+	args := []string{"-style", "arrows", "-depth", "2"}
+	loadStyleFile.Do(func() {
+		args = append([]string{"-style-file", "testdata/styles.txt"}, args...)
+	})
+
+	code, output, errOutput := runWith(pathsInput, args...)
+	assert.Equal(t, 0, code, errOutput)
+	assert.Equal(t, `src
+|>- main.go
+ `+"`"+`- util
+README.md
+docs
+|>- file10.md
+ `+"`"+`- file2.md
+`, output)
+}
+
+func TestRun_Hyperlinks(t *testing.T) {
+	input := `[{"label": "docs", "url": "https://example.com/docs", "branches": [{"label": "a"}]}]`
+	_, output, _ := runWith(input, "-hyperlinks", "on")
+	assert.Equal(t, "\x1b]8;;https://example.com/docs\x1b\\docs\x1b]8;;\x1b\\\n╰── a\n", output)
+
+	_, output, _ = runWith(input, "-hyperlinks", "off")
+	assert.Equal(t, "docs\n╰── a\n", output)
+
+	// auto only prints hyperlinks to a terminal
+	os.Setenv("FORCE_HYPERLINK", "1")
+	defer os.Unsetenv("FORCE_HYPERLINK")
+	_, output, _ = runWith(input)
+	assert.Equal(t, "docs\n╰── a\n", output)
+
+	// the urls are kept in the JSON output
+	_, output, _ = runWith(input, "-output", "json")
+	assert.Contains(t, output, `"url": "https://example.com/docs"`)
+
+	code, _, errors := runWith(input, "-hyperlinks", "maybe")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errors, `unknown hyperlink mode "maybe"`)
+}
+
+func TestRun_Icons(t *testing.T) {
+	_, output, _ := runWith(pathsInput, "-icons")
+	assert.Equal(t, `📁 src
+├── 🐹 main.go
+╰── 📁 util
+    ├── 🐹 a.go
+    ╰── 🐹 b.go
+📝 README.md
+📁 docs
+├── 📝 file10.md
+╰── 📝 file2.md
+`, output)
+
+	_, output, _ = runWith(`[{"label": "a", "icon": "★"}]`, "-output", "json")
+	assert.Contains(t, output, `"icon": "★"`)
+}
+
+func TestRun_Root(t *testing.T) {
+	_, output, _ := runWith(pathsInput, "-root", "-depth", "1")
+	assert.Equal(t, `.
+├── src
+├── README.md
+╰── docs
+`, output)
+}
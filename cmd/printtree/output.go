@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kevmurray/printtree"
+)
+
+// writeTree writes the tree in the output format
+func writeTree(w io.Writer, tree *printtree.Tree, opts options) error {
+	switch opts.output {
+	case "text":
+		style, err := printtree.StyleByName(opts.style)
+		if err != nil {
+			return err
+		}
+		layout, ok := layouts[opts.layout]
+		if !ok {
+			return fmt.Errorf("unknown layout %q", opts.layout)
+		}
+		links, ok := hyperlinks[opts.links]
+		if !ok {
+			return fmt.Errorf("unknown hyperlink mode %q", opts.links)
+		}
+		if links == printtree.HyperlinksAuto && !isTerminal(w) {
+			// hyperlinks are only useful in a terminal, not in a file or a pipe
+			links = printtree.HyperlinksOff
+		}
+		printOptions := []printtree.PrintOption{printtree.WithLayout(layout), printtree.WithHyperlinks(links)}
+		if opts.root {
+			printOptions = append(printOptions, printtree.WithVisibleRoot())
+		}
+		if opts.icons {
+			printOptions = append(printOptions, printtree.WithIconFunc(printtree.FileIcon))
+		}
+		_, err = io.WriteString(w, tree.PrintStyle(style, printOptions...))
+		return err
+	case "indent":
+		_, err := io.WriteString(w, tree.String())
+		return err
+	case "paths":
+		return writePaths(w, tree, nil, opts.separator)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(toJSON(tree).Branches)
+	}
+	return fmt.Errorf("unknown output format %q", opts.output)
+}
+
+// isTerminal returns true if the writer is a terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// writePaths writes the path of every branch of the tree on its own line, parents first
+func writePaths(w io.Writer, tree *printtree.Tree, path []string, separator string) error {
+	for _, branch := range tree.Branches {
+		branchPath := append(path[:len(path):len(path)], branch.Label)
+		if _, err := fmt.Fprintln(w, strings.Join(branchPath, separator)); err != nil {
+			return err
+		}
+		if err := writePaths(w, branch, branchPath, separator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toJSON converts the tree to its JSON form
+func toJSON(tree *printtree.Tree) *jsonBranch {
+	branch := &jsonBranch{Label: tree.Label, URL: tree.URL, Icon: tree.Icon}
+	for _, child := range tree.Branches {
+		branch.Branches = append(branch.Branches, toJSON(child))
+	}
+	if branch.Branches == nil && tree.Label == "" {
+		branch.Branches = []*jsonBranch{}
+	}
+	return branch
+}
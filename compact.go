@@ -0,0 +1,89 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// CompactOption customizes how Compact merges chains of branches
+type CompactOption func(*compactConfig)
+
+type compactConfig struct {
+	minChain int // the shortest chain of branches that is merged
+}
+
+// WithMinChain only merges chains of at least the given number of branches. For example, with a
+// minimum of 3, "com → example → app" is merged into "com/example/app" but "src → main" is left
+// alone. The default is 2, which merges every branch that has a single branch
+func WithMinChain(length int) CompactOption {
+	return func(config *compactConfig) {
+		if length >= 2 {
+			config.minChain = length
+		}
+	}
+}
+
+// Compact merges each chain of branches that have a single branch into one branch, with the
+// labels of the chain joined by the separator. This shortens deep package and directory trees
+// where most levels have nothing but the next level
+//   com                 com/example/app
+//   ╰── example   =>    ├── Main.java
+//       ╰── app         ╰── Util.java
+//           ├── Main.java
+//           ╰── Util.java
+// A chain stops at a branch that carries metadata (such as a Payload, Collapsed, URL or Icon),
+// so that no metadata is lost. The merged branch carries the metadata and the branches of the
+// last branch of the chain.
+// The branches of this tree are compacted, but this tree itself is never merged into its branch
+func (tree *Tree) Compact(separator string, options ...CompactOption) {
+	config := &compactConfig{minChain: 2}
+	for _, option := range options {
+		option(config)
+	}
+	tree.compact(separator, config, ancestry{tree: true})
+}
+
+// compact is the internal, recursive hook for Compact
+func (tree *Tree) compact(separator string, config *compactConfig, ancestors ancestry) {
+	for _, branch := range tree.Branches {
+		if ancestors[branch] {
+			continue
+		}
+
+		// follow the chain while it has a single branch that does not loop back
+		chain := []*Tree{branch}
+		last := branch
+		ancestors[branch] = true
+		for len(last.Branches) == 1 && !last.hasMetadata() && !ancestors[last.Branches[0]] {
+			last = last.Branches[0]
+			ancestors[last] = true
+			chain = append(chain, last)
+		}
+		for _, link := range chain[1:] {
+			delete(ancestors, link)
+		}
+
+		if len(chain) >= config.minChain {
+			labels := make([]string, 0, len(chain))
+			for _, link := range chain {
+				labels = append(labels, link.Label)
+			}
+			branch.Label = strings.Join(labels, separator)
+			branch.Payload = last.Payload
+			branch.Collapsed = last.Collapsed
+			branch.URL = last.URL
+			branch.Icon = last.Icon
+			branch.Branches = last.Branches
+			for _, child := range branch.Branches {
+				branch.adopt(child)
+			}
+		}
+
+		branch.compact(separator, config, ancestors)
+		delete(ancestors, branch)
+	}
+}
+
+// hasMetadata returns true if the branch carries anything other than its label and branches
+func (tree *Tree) hasMetadata() bool {
+	return tree.Payload != nil || tree.Collapsed || tree.URL != "" || tree.Icon != ""
+}
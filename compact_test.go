@@ -0,0 +1,133 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	tree := NewTree()
+	app := tree.AddBranch("com").AddBranch("example").AddBranch("app")
+	app.AddBranches("Main.java", "Util.java")
+	app.AddBranch("model").AddBranch("User.java")
+	tree.AddBranch("src").AddBranch("main")
+	tree.Compact("/")
+
+	assert.Equal(t, `com/example/app
+├── Main.java
+├── Util.java
+╰── model/User.java
+src/main
+`, tree.Print())
+
+	// the merged branches have the right parents
+	user := tree.Branches[0].Branches[2]
+	assert.Equal(t, []string{"com/example/app", "model/User.java"}, user.Path())
+}
+
+func TestCompact_MinChain(t *testing.T) {
+	tree := NewTree()
+	app := tree.AddBranch("com").AddBranch("example").AddBranch("app")
+	app.AddBranches("Main.java", "Util.java")
+	app.AddBranch("model").AddBranch("User.java")
+	tree.AddBranch("src").AddBranch("main")
+	tree.Compact(".", WithMinChain(3))
+
+	assert.Equal(t, `com.example.app
+├── Main.java
+├── Util.java
+╰── model
+    ╰── User.java
+src
+╰── main
+`, tree.Print())
+}
+
+func TestCompact_Metadata(t *testing.T) {
+	tree := NewTree()
+	example := tree.AddBranch("com").AddBranch("example")
+	example.Payload = "module"
+	app := example.AddBranch("app")
+	app.AddBranches("Main.java", "Util.java")
+	app.AddBranch("model").AddBranch("User.java")
+	tree.AddBranch("src").AddBranch("main")
+	tree.Compact("/")
+
+	assert.Equal(t, `com/example
+╰── app
+    ├── Main.java
+    ├── Util.java
+    ╰── model/User.java
+src/main
+`, tree.Print())
+	assert.Equal(t, "module", tree.Branches[0].Payload)
+
+	// the metadata of the last branch of a chain is kept by the merged branch
+	tree = NewTree()
+	app = tree.AddBranch("com").AddBranch("example").AddBranch("app")
+	app.AddBranches("Main.java", "Util.java")
+	user := app.AddBranch("model").AddBranch("User.java")
+	user.URL = "https://example.com/User.java"
+	user.Icon = "☕"
+	tree.AddBranch("src").AddBranch("main").Collapsed = true
+	tree.Compact("/")
+
+	merged := tree.Branches[0].Branches[2]
+	assert.Equal(t, "model/User.java", merged.Label)
+	assert.Equal(t, "https://example.com/User.java", merged.URL)
+	assert.Equal(t, "☕", merged.Icon)
+	assert.Equal(t, "src/main", tree.Branches[1].Label)
+	assert.True(t, tree.Branches[1].Collapsed)
+}
+
+func TestCompact_Cycle(t *testing.T) {
+	tree := NewTree()
+	a := tree.AddBranch("a")
+	a.AddBranch("b").AddTreeAsBranch(a)
+	tree.Compact("/")
+
+	assert.Equal(t, `a/b
+╰── ↻ (cycle to a/b)
+`, tree.Print())
+}
+
+func TestWithCompact(t *testing.T) {
+	tree := NewTree()
+	app := tree.AddBranch("com").AddBranch("example").AddBranch("app")
+	app.AddBranches("Main.java", "Util.java")
+	app.AddBranch("model").AddBranch("User.java")
+	tree.AddBranch("src").AddBranch("main")
+
+	assert.Equal(t, `com/example/app
+├── Main.java
+├── Util.java
+╰── model/User.java
+src/main
+`, tree.PrintStyle(BoxStyle, WithCompact("/")))
+
+	assert.Equal(t, `com.example.app
+├── Main.java
+├── Util.java
+╰── model
+    ╰── User.java
+src
+╰── main
+`, tree.PrintStyle(BoxStyle, WithCompact(".", WithMinChain(3))))
+
+	// the tree itself is not changed
+	assert.Equal(t, "com", tree.Branches[0].Label)
+}
+
+func ExampleTree_Compact() {
+	tree := NewTree()
+	tree.AddBranch("com").AddBranch("example").AddBranch("app").AddBranches("Main.java", "Util.java")
+
+	tree.Compact(".")
+	fmt.Print(tree.Print())
+	// Output:
+	// com.example.app
+	// ├── Main.java
+	// ╰── Util.java
+}
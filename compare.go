@@ -0,0 +1,180 @@
+package printtree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+)
+
+// Clone returns a deep copy of this tree. The copy has the same shape as the original: a branch
+// that is shared by several parents in the original (see AddTreeAsBranch) is copied once and
+// shared the same way in the copy. Payloads are copied by assignment, so a payload that is a
+// pointer, map or slice is shared by both trees
+func (tree *Tree) Clone() *Tree {
+	return tree.clone(make(map[*Tree]*Tree))
+}
+
+// clone is the internal, recursive hook for Clone. The copies map holds the copy of every branch
+// that has already been copied
+func (tree *Tree) clone(copies map[*Tree]*Tree) *Tree {
+	if clone, ok := copies[tree]; ok {
+		return clone
+	}
+
+	clone := &Tree{
+		Label:     tree.Label,
+		Payload:   tree.Payload,
+		Collapsed: tree.Collapsed,
+		URL:       tree.URL,
+		Icon:      tree.Icon,
+	}
+	copies[tree] = clone
+	if tree.Branches != nil {
+		clone.Branches = make([]*Tree, 0, len(tree.Branches))
+		for _, branch := range tree.Branches {
+			branchClone := branch.clone(copies)
+			clone.Branches = append(clone.Branches, branchClone)
+			clone.adopt(branchClone)
+		}
+	}
+	return clone
+}
+
+// Equal returns true if the other tree has the same labels, payloads, metadata (Collapsed, URL
+// and Icon) and branches, in the same order, as this tree. Payloads are compared with
+// reflect.DeepEqual. A branch that loops back
+// to one of its ancestors is compared like the "↻" leaf it is printed as
+func (tree *Tree) Equal(other *Tree) bool {
+	if tree == nil || other == nil {
+		return tree == other
+	}
+	return tree.equal(other, ancestry{tree: true}, ancestry{other: true})
+}
+
+// equal is the internal, recursive hook for Equal. The ancestries are those of this tree and
+// the other tree
+func (tree *Tree) equal(other *Tree, ancestors ancestry, otherAncestors ancestry) bool {
+	if !tree.equalNode(other) {
+		return false
+	}
+	for index := range tree.Branches {
+		branch := ancestors.follow(tree.Branches[index])
+		otherBranch := otherAncestors.follow(other.Branches[index])
+		ancestors[branch], otherAncestors[otherBranch] = true, true
+		equal := branch.equal(otherBranch, ancestors, otherAncestors)
+		delete(ancestors, branch)
+		delete(otherAncestors, otherBranch)
+		if !equal {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered returns true if the other tree has the same labels, payloads, metadata and
+// branches as this tree, in any order. Payloads are compared with reflect.DeepEqual. Like Equal,
+// branches that loop back to an ancestor are compared like the leaves they are printed as
+func (tree *Tree) EqualUnordered(other *Tree) bool {
+	if tree == nil || other == nil {
+		return tree == other
+	}
+	return tree.equalUnordered(other, ancestry{tree: true}, ancestry{other: true})
+}
+
+// equalUnordered is the internal, recursive hook for EqualUnordered
+func (tree *Tree) equalUnordered(other *Tree, ancestors ancestry, otherAncestors ancestry) bool {
+	if !tree.equalNode(other) {
+		return false
+	}
+	if tree.hash(false, ancestors) != other.hash(false, otherAncestors) {
+		return false
+	}
+
+	// pair up each branch with an equal branch of the other tree
+	matched := make([]bool, len(other.Branches))
+	for _, branch := range tree.Branches {
+		branch = ancestors.follow(branch)
+		ancestors[branch] = true
+		found := false
+		for index, candidate := range other.Branches {
+			if matched[index] {
+				continue
+			}
+			candidate = otherAncestors.follow(candidate)
+			otherAncestors[candidate] = true
+			equal := branch.equalUnordered(candidate, ancestors, otherAncestors)
+			delete(otherAncestors, candidate)
+			if equal {
+				matched[index] = true
+				found = true
+				break
+			}
+		}
+		delete(ancestors, branch)
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// equalNode compares this node with the other node, without comparing the branches themselves
+func (tree *Tree) equalNode(other *Tree) bool {
+	return tree.Label == other.Label &&
+		tree.Collapsed == other.Collapsed &&
+		tree.URL == other.URL &&
+		tree.Icon == other.Icon &&
+		len(tree.Branches) == len(other.Branches) &&
+		reflect.DeepEqual(tree.Payload, other.Payload)
+}
+
+// Hash returns a hash of the contents (labels, payloads, metadata and the order of branches) of
+// this tree. Equal trees have equal hashes, so the hash can be used to cache results per subtree
+// or to quickly detect that a subtree changed. Payloads are hashed by their Go syntax
+// representation (the %#v verb) so the hash is stable between runs as long as payloads do not
+// contain pointers
+func (tree *Tree) Hash() uint64 {
+	return tree.hash(true, ancestry{tree: true})
+}
+
+// hash is the internal, recursive hook for Hash. If ordered is false, the hash does not depend on
+// the order of the branches. Branches that loop back to an ancestor are hashed like the leaves
+// they are printed as
+func (tree *Tree) hash(ordered bool, ancestors ancestry) uint64 {
+	branchHashes := make([]uint64, 0, len(tree.Branches))
+	for _, branch := range tree.Branches {
+		branch = ancestors.follow(branch)
+		ancestors[branch] = true
+		branchHashes = append(branchHashes, branch.hash(ordered, ancestors))
+		delete(ancestors, branch)
+	}
+	if !ordered {
+		sort.Slice(branchHashes, func(i, j int) bool {
+			return branchHashes[i] < branchHashes[j]
+		})
+	}
+
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	writeString := func(s string) {
+		binary.BigEndian.PutUint64(buf, uint64(len(s)))
+		_, _ = h.Write(buf)
+		_, _ = h.Write([]byte(s))
+	}
+	writeString(tree.Label)
+	writeString(fmt.Sprint(tree.Collapsed))
+	writeString(tree.URL)
+	writeString(tree.Icon)
+	if tree.Payload != nil {
+		writeString(fmt.Sprintf("%#v", tree.Payload))
+	} else {
+		writeString("")
+	}
+	for _, branchHash := range branchHashes {
+		binary.BigEndian.PutUint64(buf, branchHash)
+		_, _ = h.Write(buf)
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,171 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").Payload = 1
+	b := root.AddBranch("b")
+	b.AddBranches("i", "ii")
+	root.AddBranch("c").Payload = map[string]int{"size": 3}
+
+	clone := tree.Clone()
+	assert.True(t, tree.Equal(clone))
+	assert.Equal(t, tree.Print(), clone.Print())
+
+	// changing the clone does not change the original
+	clone.Branches[0].Branches[1].AddBranch("iii")
+	clone.Branches[0].Branches[0].Label = "A"
+	clone.Branches[0].DeepSortCustom(func(b1, b2 *Tree) bool {
+		return b1.Label > b2.Label
+	})
+	assert.Equal(t, "a", root.Branches[0].Label)
+	assert.Len(t, b.Branches, 2)
+	assert.Equal(t, "iii", clone.Branches[0].Branches[1].Branches[0].Label)
+	assert.Equal(t, "i", b.Branches[0].Label)
+}
+
+func TestClone_Shared(t *testing.T) {
+	shared := NewTree().AddBranch("shared")
+	tree := NewTree()
+	tree.AddBranch("one").AddTreeAsBranch(shared)
+	tree.AddBranch("two").AddTreeAsBranch(shared)
+
+	clone := tree.Clone()
+
+	// the shared branch is shared in the copy too, but not with the original
+	assert.Same(t, clone.Branches[0].Branches[0], clone.Branches[1].Branches[0])
+	assert.NotSame(t, shared, clone.Branches[0].Branches[0])
+}
+
+func TestEqual(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").Payload = 1
+	b := root.AddBranch("b")
+	b.AddBranches("i", "ii")
+	root.AddBranch("c").Payload = map[string]int{"size": 3}
+
+	assert.True(t, tree.Equal(tree.Clone()))
+
+	other := tree.Clone()
+	other.Branches[0].Branches[2].Payload = map[string]int{"size": 4}
+	assert.False(t, tree.Equal(other))
+
+	other = tree.Clone()
+	other.Branches[0].Branches[1].AddBranch("iii")
+	assert.False(t, tree.Equal(other))
+
+	other = tree.Clone()
+	other.Branches[0].Branches[1].Branches[0].Label = "I"
+	assert.False(t, tree.Equal(other))
+
+	// order matters
+	other = tree.Clone()
+	other.Branches[0].Branches[1].DeepSortCustom(func(b1, b2 *Tree) bool {
+		return b1.Label > b2.Label
+	})
+	assert.False(t, tree.Equal(other))
+
+	// metadata changes the printed tree, so it counts too
+	other = tree.Clone()
+	other.Branches[0].Branches[1].Collapsed = true
+	assert.False(t, tree.Equal(other))
+	other = tree.Clone()
+	other.Branches[0].URL = "https://example.com/root"
+	assert.False(t, tree.Equal(other))
+	other = tree.Clone()
+	other.Branches[0].Icon = "📁"
+	assert.False(t, tree.Equal(other))
+	assert.False(t, tree.EqualUnordered(other))
+
+	var nilTree *Tree
+	assert.False(t, tree.Equal(nil))
+	assert.True(t, nilTree.Equal(nil))
+}
+
+func TestEqualUnordered(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").Payload = 1
+	b := root.AddBranch("b")
+	b.AddBranches("i", "ii")
+	root.AddBranch("c").Payload = map[string]int{"size": 3}
+
+	other := tree.Clone()
+	other.DeepSortCustom(func(b1, b2 *Tree) bool {
+		return b1.Label > b2.Label
+	})
+	assert.False(t, tree.Equal(other))
+	assert.True(t, tree.EqualUnordered(other))
+
+	other.Branches[0].Branches[0].Payload = "different"
+	assert.False(t, tree.EqualUnordered(other))
+
+	// duplicate branches must be matched one to one
+	one := NewTree()
+	one.AddBranches("a", "a", "b")
+	two := NewTree()
+	two.AddBranches("a", "b", "b")
+	assert.False(t, one.EqualUnordered(two))
+}
+
+func TestHash(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").Payload = 1
+	b := root.AddBranch("b")
+	b.AddBranches("i", "ii")
+	root.AddBranch("c").Payload = map[string]int{"size": 3}
+
+	assert.Equal(t, tree.Hash(), tree.Clone().Hash())
+
+	other := tree.Clone()
+	other.Branches[0].Branches[0].Payload = 2
+	assert.NotEqual(t, tree.Hash(), other.Hash())
+
+	// the hash of an unchanged subtree does not change
+	assert.Equal(t, b.Hash(), other.Branches[0].Branches[1].Hash())
+
+	other = tree.Clone()
+	other.Branches[0].Branches[0].URL = "https://example.com/a"
+	assert.NotEqual(t, tree.Hash(), other.Hash())
+	other = tree.Clone()
+	other.Branches[0].Branches[0].Icon = "📄"
+	assert.NotEqual(t, tree.Hash(), other.Hash())
+	other = tree.Clone()
+	other.Branches[0].Branches[1].Collapsed = true
+	assert.NotEqual(t, tree.Hash(), other.Hash())
+
+	// labels can not run into each other
+	one := NewTree()
+	one.AddBranches("ab", "c")
+	two := NewTree()
+	two.AddBranches("a", "bc")
+	assert.NotEqual(t, one.Hash(), two.Hash())
+}
+
+func ExampleTree_Clone() {
+	template := NewTree()
+	template.AddBranch("project").AddBranches("src", "docs")
+
+	tree := NewTree()
+	tree.AddTreeAsBranch(template.Clone())
+	tree.Branches[0].Label = "my-project"
+
+	fmt.Print(template.Print())
+	fmt.Print(tree.Print())
+	// Output:
+	// project
+	// ├── src
+	// ╰── docs
+	// my-project
+	// ├── src
+	// ╰── docs
+}
@@ -0,0 +1,106 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCycle is returned by Validate when a branch is one of its own ancestors
+var ErrCycle = errors.New("cycle")
+
+// ErrSharedBranch is returned by Validate when a branch can be reached through more than one
+// parent
+var ErrSharedBranch = errors.New("shared branch")
+
+// cycleMarker is the label printed in place of a branch that loops back to one of its ancestors
+const cycleMarker = "↻ (cycle to %s)"
+
+// Validate checks that this tree really is a tree. Grafting trees with AddTreeAsBranch can
+// create branches that are their own ancestors (cycles), or branches that have more than one
+// parent (shared branches). Cycles are reported before shared branches, so a tree that is
+// allowed to share branches (such as a dependency graph) can be checked with
+//   if err := tree.Validate(); err != nil && !errors.Is(err, ErrSharedBranch) {
+//       ...
+//   }
+// The error wraps ErrCycle or ErrSharedBranch and names the path of labels that leads to the
+// offending branch
+func (tree *Tree) Validate() error {
+	v := &validator{
+		ancestors: ancestry{tree: true},
+		visited:   map[*Tree]bool{tree: true},
+	}
+	v.validate(tree, nil)
+	if v.cycle != nil {
+		return v.cycle
+	}
+	return v.shared
+}
+
+// validator holds the state of a single call to Validate
+type validator struct {
+	ancestors ancestry
+	visited   map[*Tree]bool
+	cycle     error // the first cycle found
+	shared    error // the first shared branch found
+}
+
+// validate recursively checks the branches of the tree. The path is the labels from the root to
+// the tree
+func (v *validator) validate(tree *Tree, path []string) {
+	for _, branch := range tree.Branches {
+		branchPath := appendPath(path, branch.Label)
+		switch {
+		case v.ancestors[branch]:
+			if v.cycle == nil {
+				v.cycle = fmt.Errorf("%w at %q", ErrCycle, "/"+strings.Join(branchPath, "/"))
+			}
+			continue
+		case v.visited[branch]:
+			// the branches of a shared branch have been checked when it was first visited
+			if v.shared == nil {
+				v.shared = fmt.Errorf("%w at %q", ErrSharedBranch, "/"+strings.Join(branchPath, "/"))
+			}
+			continue
+		}
+
+		v.visited[branch] = true
+		v.ancestors[branch] = true
+		v.validate(branch, branchPath)
+		delete(v.ancestors, branch)
+	}
+}
+
+// ancestry is the set of branches on the path from the root to the branch that is being
+// visited. Recursive functions use it to stop at branches that loop back to an ancestor
+type ancestry map[*Tree]bool
+
+// unrolled returns a copy of the tree in which every branch that loops back to one of its
+// ancestors is replaced by the leaf it is printed as, so the copy can be recursed without
+// checking for cycles
+func (tree *Tree) unrolled(ancestors ancestry) *Tree {
+	copy := &Tree{
+		Label:     tree.Label,
+		Payload:   tree.Payload,
+		Collapsed: tree.Collapsed,
+		URL:       tree.URL,
+		Icon:      tree.Icon,
+	}
+	for _, branch := range tree.Branches {
+		branch = ancestors.follow(branch)
+		ancestors[branch] = true
+		copy.Branches = append(copy.Branches, branch.unrolled(ancestors))
+		delete(ancestors, branch)
+	}
+	return copy
+}
+
+// follow returns the branch that a recursive function should descend into. A branch that is one
+// of its own ancestors is replaced by a leaf with a marker label, so the recursion stops there
+func (a ancestry) follow(branch *Tree) *Tree {
+	if !a[branch] {
+		return branch
+	}
+	label := strings.SplitN(branch.Label, "\n", 2)[0]
+	return &Tree{Label: fmt.Sprintf(cycleMarker, label)}
+}
@@ -0,0 +1,163 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("root").AddBranches("a", "b")
+	assert.NoError(t, tree.Validate())
+
+	cyclic := NewTree()
+	root := cyclic.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+	err := cyclic.Validate()
+	assert.True(t, errors.Is(err, ErrCycle))
+	assert.EqualError(t, err, `cycle at "/root/a/b/root"`)
+
+	shared := NewTree().AddBranch("shared")
+	tree = NewTree()
+	tree.AddBranch("one").AddTreeAsBranch(shared)
+	tree.AddBranch("two").AddTreeAsBranch(shared)
+	err = tree.Validate()
+	assert.True(t, errors.Is(err, ErrSharedBranch))
+	assert.EqualError(t, err, `shared branch at "/two/shared"`)
+
+	// cycles are reported before shared branches
+	tree.Branches[1].AddTreeAsBranch(root)
+	assert.True(t, errors.Is(tree.Validate(), ErrCycle))
+}
+
+func TestCycle_Print(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+
+	assert.Equal(t, `root
+╰── a
+    ├── leaf
+    ╰── b
+        ╰── ↻ (cycle to root)
+`, tree.Print())
+
+	assert.Equal(t, `    ╭── leaf
+    │   ╭── ↻ (cycle to root)
+    ├── b
+╭── a
+root
+`, tree.PrintStyle(BoxStyle, WithInverted()))
+
+	assert.Equal(t, `root ─── a ─┬─ leaf
+            ╰─ b ─── ↻ (cycle to root)
+`, tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(0)))
+
+	assert.Equal(t, `      root
+       │
+       a
+ ╭─────┴──────╮
+leaf          b
+              │
+      ↻ (cycle to root)
+`, tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0)))
+}
+
+func TestCycle_Traverse(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+
+	assert.Equal(t, 4, tree.Depth())
+
+	tree.DeepSort()
+	assert.Equal(t, `root
+╰── a
+    ├── b
+    │   ╰── ↻ (cycle to root)
+    ╰── leaf
+`, tree.Print())
+}
+
+func TestCycle_Shared(t *testing.T) {
+	// a shared branch is not a cycle and is printed in full under every parent
+	shared := NewTree().AddBranch("shared")
+	shared.AddBranch("child")
+	tree := NewTree()
+	tree.AddBranch("one").AddTreeAsBranch(shared)
+	tree.AddBranch("two").AddTreeAsBranch(shared)
+
+	assert.Equal(t, `one
+╰── shared
+    ╰── child
+two
+╰── shared
+    ╰── child
+`, tree.Print())
+}
+
+func TestCycle_Compare(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddTreeAsBranch(root)
+	other := NewTree()
+	otherRoot := other.AddBranch("root")
+	otherRoot.AddBranch("a").AddTreeAsBranch(otherRoot)
+
+	assert.True(t, tree.Equal(other))
+	assert.True(t, tree.EqualUnordered(other))
+	assert.Equal(t, tree.Hash(), other.Hash())
+
+	otherRoot.AddBranch("b")
+	assert.False(t, tree.Equal(other))
+	assert.False(t, tree.EqualUnordered(other))
+	assert.NotEqual(t, tree.Hash(), other.Hash())
+}
+
+func TestCycle_Merge(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddTreeAsBranch(root)
+
+	// the loop back to root is not merged again
+	merged := Merge(tree, tree)
+	assert.Equal(t, `root
+╰── a
+`, merged.Print())
+}
+
+func TestCycle_Diff(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddTreeAsBranch(root)
+	other := NewTree()
+	other.AddBranch("root").AddBranch("a")
+
+	assert.Equal(t, `  root
+      a
+        - ↻ (cycle to root)
+`, Diff(tree, other, WithUnchanged()).String())
+}
+
+func ExampleTree_Validate() {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("child").AddTreeAsBranch(root)
+
+	fmt.Println(tree.Validate())
+	fmt.Print(tree.Print())
+	// Output:
+	// cycle at "/root/child/root"
+	// root
+	// ╰── child
+	//     ╰── ↻ (cycle to root)
+}
@@ -0,0 +1,397 @@
+package printtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Change is the kind of change a branch went through between two trees
+type Change int
+
+const (
+	// Unchanged branches are in both trees at the same path
+	Unchanged Change = iota
+	// Added branches are only in the second tree
+	Added
+	// Removed branches are only in the first tree
+	Removed
+	// Moved branches are in both trees, but at different paths
+	Moved
+)
+
+// ANSI escape sequences used to color the changes
+const (
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// KeyFunc returns the key that identifies a branch among its siblings. Branches are matched with
+// each other when their keys, and the keys of all their ancestors, are equal
+type KeyFunc func(branch *Tree) string
+
+// LabelKey is a KeyFunc that identifies branches by their label
+func LabelKey(branch *Tree) string {
+	return branch.Label
+}
+
+// DiffBranch is a branch of a TreeDiff. It describes how a single branch changed between the two
+// trees that were compared
+type DiffBranch struct {
+	Label    string
+	Change   Change
+	From     []string // for moved branches, the path of labels the branch was moved from
+	Branches []*DiffBranch
+}
+
+// TreeDiff is the result of comparing two trees with Diff
+type TreeDiff struct {
+	Branches []*DiffBranch
+	config   *diffConfig
+}
+
+// DiffOption customizes how Diff compares trees and how the result is printed
+type DiffOption func(*diffConfig)
+
+type diffConfig struct {
+	key       KeyFunc
+	color     bool // color the changes with ANSI escape sequences
+	unchanged bool // print unchanged subtrees in full
+}
+
+// WithDiffKey identifies branches with a custom key instead of their label. For example, to
+// ignore differences in case
+//   Diff(a, b, WithDiffKey(func(branch *Tree) string {
+//       return strings.ToLower(branch.Label)
+//   }))
+func WithDiffKey(key KeyFunc) DiffOption {
+	return func(config *diffConfig) {
+		if key != nil {
+			config.key = key
+		}
+	}
+}
+
+// WithDiffColor colors the printed changes with ANSI escape sequences: green for added
+// branches, red for removed branches and yellow for moved branches
+func WithDiffColor() DiffOption {
+	return func(config *diffConfig) {
+		config.color = true
+	}
+}
+
+// WithUnchanged prints unchanged subtrees in full. By default, subtrees without any changes are
+// collapsed into their top branch
+func WithUnchanged() DiffOption {
+	return func(config *diffConfig) {
+		config.unchanged = true
+	}
+}
+
+// Diff compares two trees and returns the changes needed to turn the first tree into the second.
+// Branches are matched by the path of labels from the root (see WithDiffKey to match them
+// differently). Branches that are only in the first tree are Removed, those only in the second
+// are Added, and those that appear in both trees at different paths are Moved. Branches that
+// loop back to one of their ancestors are compared like the "↻" leaves they are printed as
+func Diff(a, b *Tree, options ...DiffOption) *TreeDiff {
+	a, b = a.unrolled(ancestry{a: true}), b.unrolled(ancestry{b: true})
+	config := &diffConfig{key: LabelKey}
+	for _, option := range options {
+		option(config)
+	}
+
+	d := &differ{key: config.key}
+	diff := &TreeDiff{
+		Branches: d.diffBranches(a.Branches, b.Branches, nil),
+		config:   config,
+	}
+	diff.Branches = pruneBranches(diff.Branches, d.findMoves())
+	return diff
+}
+
+// differ holds the state of a single call to Diff
+type differ struct {
+	key     KeyFunc
+	added   []*diffRoot
+	removed []*diffRoot
+}
+
+// diffRoot is a branch in a subtree that was added or removed
+type diffRoot struct {
+	tree     *Tree
+	diff     *DiffBranch
+	path     []string
+	children []*diffRoot
+	moved    bool // true if the branch has been paired up as part of a move
+}
+
+// diffBranches compares the branches of two trees. The path is the labels of the (first) tree
+// that the branches belong to
+func (d *differ) diffBranches(aBranches, bBranches []*Tree, path []string) []*DiffBranch {
+	matched := make([]bool, len(aBranches))
+	counterparts := make([]*DiffBranch, len(aBranches))
+	result := make([]*DiffBranch, 0, len(bBranches))
+
+	for _, bBranch := range bBranches {
+		index := d.match(bBranch, aBranches, matched)
+		if index < 0 {
+			added := changedBranch(bBranch, Added)
+			d.added = append(d.added, &diffRoot{tree: bBranch, diff: added, path: appendPath(path, bBranch.Label)})
+			result = append(result, added)
+			continue
+		}
+
+		matched[index] = true
+		branch := &DiffBranch{
+			Label:    bBranch.Label,
+			Change:   Unchanged,
+			Branches: d.diffBranches(aBranches[index].Branches, bBranch.Branches, appendPath(path, aBranches[index].Label)),
+		}
+		counterparts[index] = branch
+		result = append(result, branch)
+	}
+
+	// removed branches go right after the branch that preceded them in the first tree
+	insertAt := 0
+	for index, aBranch := range aBranches {
+		if matched[index] {
+			insertAt = indexOfBranch(result, counterparts[index]) + 1
+			continue
+		}
+		removed := changedBranch(aBranch, Removed)
+		d.removed = append(d.removed, &diffRoot{tree: aBranch, diff: removed, path: appendPath(path, aBranch.Label)})
+		result = append(result[:insertAt], append([]*DiffBranch{removed}, result[insertAt:]...)...)
+		insertAt++
+	}
+
+	return result
+}
+
+// match returns the index of the first unmatched branch with the same key as the branch, or -1
+// if there is none
+func (d *differ) match(branch *Tree, candidates []*Tree, matched []bool) int {
+	key := d.key(branch)
+	for index, candidate := range candidates {
+		if !matched[index] && d.key(candidate) == key {
+			return index
+		}
+	}
+	return -1
+}
+
+// findMoves pairs up branches in subtrees that were added with branches in subtrees that were
+// removed and have the same key. Ancestors are paired before their descendants, so whole
+// subtrees move together when they can. The added branch is turned into a moved branch (and
+// compared against the removed one). Returns the removed branches that were moved, which must be
+// dropped from the diff
+func (d *differ) findMoves() map[*DiffBranch]bool {
+	added := flattenRoots(d.added)
+	removed := flattenRoots(d.removed)
+	for _, candidate := range added {
+		if candidate.moved {
+			continue
+		}
+		key := d.key(candidate.tree)
+		for _, origin := range removed {
+			if origin.moved || d.key(origin.tree) != key {
+				continue
+			}
+			origin.markMoved()
+			candidate.markMoved()
+			candidate.diff.Change = Moved
+			candidate.diff.From = origin.path
+			candidate.diff.Branches = d.diffBranches(origin.tree.Branches, candidate.tree.Branches, origin.path)
+			break
+		}
+	}
+
+	moved := make(map[*DiffBranch]bool)
+	for _, origin := range removed {
+		if origin.moved {
+			moved[origin.diff] = true
+		}
+	}
+	return moved
+}
+
+// flattenRoots returns the roots, and all their descendants, in depth first order
+func flattenRoots(roots []*diffRoot) []*diffRoot {
+	var result []*diffRoot
+	var flatten func(root *diffRoot)
+	flatten = func(root *diffRoot) {
+		result = append(result, root)
+		for index, child := range root.tree.Branches {
+			childRoot := &diffRoot{
+				tree: child,
+				diff: root.diff.Branches[index],
+				path: appendPath(root.path, child.Label),
+			}
+			root.children = append(root.children, childRoot)
+			flatten(childRoot)
+		}
+	}
+	for _, root := range roots {
+		flatten(root)
+	}
+	return result
+}
+
+// markMoved marks this branch, and all its descendants, as moved so they are not paired up again
+func (root *diffRoot) markMoved() {
+	root.moved = true
+	for _, child := range root.children {
+		child.markMoved()
+	}
+}
+
+// pruneBranches recursively removes the given branches from the list of branches
+func pruneBranches(branches []*DiffBranch, prune map[*DiffBranch]bool) []*DiffBranch {
+	result := make([]*DiffBranch, 0, len(branches))
+	for _, branch := range branches {
+		if prune[branch] {
+			continue
+		}
+		branch.Branches = pruneBranches(branch.Branches, prune)
+		result = append(result, branch)
+	}
+	return result
+}
+
+// changedBranch returns a diff of the tree where the tree and all its branches have the same
+// change
+func changedBranch(tree *Tree, change Change) *DiffBranch {
+	branch := &DiffBranch{
+		Label:    tree.Label,
+		Change:   change,
+		Branches: make([]*DiffBranch, 0, len(tree.Branches)),
+	}
+	for _, child := range tree.Branches {
+		branch.Branches = append(branch.Branches, changedBranch(child, change))
+	}
+	return branch
+}
+
+// appendPath returns a new path with the label appended
+func appendPath(path []string, label string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, label)
+}
+
+// indexOfBranch returns the index of the branch in the list, or -1 if it is not there
+func indexOfBranch(branches []*DiffBranch, branch *DiffBranch) int {
+	for index := range branches {
+		if branches[index] == branch {
+			return index
+		}
+	}
+	return -1
+}
+
+// HasChanges returns true if the trees that were compared are different
+func (diff *TreeDiff) HasChanges() bool {
+	for _, branch := range diff.Branches {
+		if branch.HasChanges() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasChanges returns true if this branch, or any of its branches, changed
+func (branch *DiffBranch) HasChanges() bool {
+	if branch.Change != Unchanged {
+		return true
+	}
+	for _, child := range branch.Branches {
+		if child.HasChanges() {
+			return true
+		}
+	}
+	return false
+}
+
+// Tree returns the diff as a printable tree. Each label is prefixed with a marker for its
+// change: "+" for added, "-" for removed and "~" for moved branches
+func (diff *TreeDiff) Tree() *Tree {
+	tree := NewTree()
+	for _, branch := range diff.Branches {
+		branch.addTo(tree, diff.config)
+	}
+	return tree
+}
+
+// String returns the diff printed with whitespace indentation
+func (diff *TreeDiff) String() string {
+	return diff.PrintStyle(WhiteSpaceStyle)
+}
+
+// Print returns the diff printed in the default style (BoxStyle)
+func (diff *TreeDiff) Print() string {
+	return diff.PrintStyle(BoxStyle)
+}
+
+// PrintStyle returns the diff printed in any style, with a marker in front of each label that
+// shows its change
+//   + added
+//   - removed
+//   ~ moved (from old/path)
+func (diff *TreeDiff) PrintStyle(style TreeStyle, options ...PrintOption) string {
+	return diff.Tree().PrintStyle(style, options...)
+}
+
+// addTo adds this branch, and all its branches, to the tree with change markers in the labels
+func (branch *DiffBranch) addTo(tree *Tree, config *diffConfig) {
+	var marker, color string
+	switch branch.Change {
+	case Added:
+		marker, color = "+ ", ansiGreen
+	case Removed:
+		marker, color = "- ", ansiRed
+	case Moved:
+		marker, color = "~ ", ansiYellow
+	default:
+		marker = "  "
+	}
+
+	lines := strings.Split(branch.Label, "\n")
+	if branch.Change == Moved {
+		lines[0] += fmt.Sprintf(" (moved from %s)", strings.Join(branch.From, "/"))
+	}
+	hidden := 0
+	collapse := !config.unchanged && !branch.HasChanges()
+	if collapse {
+		hidden = branch.count() - 1
+		if hidden > 0 {
+			lines[0] += fmt.Sprintf(" (%d unchanged)", hidden)
+		}
+	}
+	for index := range lines {
+		if index == 0 {
+			lines[index] = marker + lines[index]
+		} else {
+			lines[index] = "  " + lines[index]
+		}
+		if config.color && color != "" {
+			lines[index] = color + lines[index] + ansiReset
+		}
+	}
+
+	child := tree.AddBranch(strings.Join(lines, "\n"))
+	if collapse {
+		return
+	}
+	for _, grandchild := range branch.Branches {
+		grandchild.addTo(child, config)
+	}
+}
+
+// count returns the number of branches in this subtree, including this branch
+func (branch *DiffBranch) count() int {
+	count := 1
+	for _, child := range branch.Branches {
+		count += child.count()
+	}
+	return count
+}
@@ -0,0 +1,175 @@
+package printtree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	before := NewTree()
+	app := before.AddBranch("app")
+	app.AddBranch("config").AddBranches("db.yml", "cache.yml")
+	app.AddBranch("lib").AddBranches("util.go", "log.go")
+	app.AddBranch("README")
+
+	after := NewTree()
+	app = after.AddBranch("app")
+	app.AddBranch("config").AddBranches("db.yml", "queue.yml")
+	app.AddBranch("lib").AddBranch("util.go")
+	app.AddBranch("internal").AddBranch("log.go")
+
+	diff := Diff(before, after)
+
+	assert.True(t, diff.HasChanges())
+	assert.Len(t, diff.Branches, 1)
+	changed := diff.Branches[0]
+	assert.Equal(t, Unchanged, changed.Change)
+	assert.Len(t, changed.Branches, 4)
+
+	config := changed.Branches[0]
+	assert.Equal(t, "config", config.Label)
+	assert.Equal(t, Unchanged, config.Branches[0].Change)
+	assert.Equal(t, "cache.yml", config.Branches[1].Label)
+	assert.Equal(t, Removed, config.Branches[1].Change)
+	assert.Equal(t, "queue.yml", config.Branches[2].Label)
+	assert.Equal(t, Added, config.Branches[2].Change)
+
+	lib := changed.Branches[1]
+	assert.Equal(t, "lib", lib.Label)
+	assert.False(t, lib.HasChanges())
+
+	assert.Equal(t, "README", changed.Branches[2].Label)
+	assert.Equal(t, Removed, changed.Branches[2].Change)
+
+	internal := changed.Branches[3]
+	assert.Equal(t, Added, internal.Change)
+	assert.Equal(t, Moved, internal.Branches[0].Change)
+	assert.Equal(t, []string{"app", "lib", "log.go"}, internal.Branches[0].From)
+}
+
+func TestDiff_Print(t *testing.T) {
+	before := NewTree()
+	app := before.AddBranch("app")
+	app.AddBranch("config").AddBranches("db.yml", "cache.yml")
+	app.AddBranch("lib").AddBranches("util.go", "log.go")
+	app.AddBranch("README")
+
+	after := NewTree()
+	app = after.AddBranch("app")
+	app.AddBranch("config").AddBranches("db.yml", "queue.yml")
+	app.AddBranch("lib").AddBranch("util.go")
+	app.AddBranch("internal").AddBranch("log.go")
+
+	result := Diff(before, after).PrintStyle(ASCIIStyle)
+	assert.Equal(t, `  app
+|--   config
+|   |--   db.yml
+|   |-- - cache.yml
+|   '-- + queue.yml
+|--   lib (1 unchanged)
+|-- - README
+'-- + internal
+    '-- ~ log.go (moved from app/lib/log.go)
+`, result)
+
+	result = Diff(before, after, WithUnchanged()).PrintStyle(ASCIIStyle)
+	assert.Equal(t, `  app
+|--   config
+|   |--   db.yml
+|   |-- - cache.yml
+|   '-- + queue.yml
+|--   lib
+|   '--   util.go
+|-- - README
+'-- + internal
+    '-- ~ log.go (moved from app/lib/log.go)
+`, result)
+}
+
+func TestDiff_Color(t *testing.T) {
+	before := NewTree()
+	before.AddBranch("root").AddBranches("old\nlines", "same")
+	after := NewTree()
+	after.AddBranch("root").AddBranches("same", "new")
+
+	result := Diff(before, after, WithDiffColor()).PrintStyle(BoxStyle)
+	assert.Equal(t, "  root\n"+
+		"├── \x1b[31m- old\x1b[0m\n"+
+		"│   \x1b[31m  lines\x1b[0m\n"+
+		"├──   same\n"+
+		"╰── \x1b[32m+ new\x1b[0m\n", result)
+
+	// colors do not count towards the width of the labels
+	result = Diff(before, after, WithDiffColor()).PrintStyle(BoxStyle, WithMirrored())
+	assert.Equal(t, "       root\n"+
+		"  \x1b[31m- old\x1b[0m ──┤\n"+
+		"\x1b[31m  lines\x1b[0m   │\n"+
+		"   same ──┤\n"+
+		"  \x1b[32m+ new\x1b[0m ──╯\n", result)
+}
+
+func TestDiff_MovedSubtree(t *testing.T) {
+	before := NewTree()
+	before.AddBranch("a").AddBranch("b").AddBranches("c", "d")
+	after := NewTree()
+	after.AddBranch("a")
+	after.AddBranch("b").AddBranches("c", "e")
+
+	result := Diff(before, after).PrintStyle(ASCIIStyle)
+	assert.Equal(t, `  a
+~ b (moved from a/b)
+|--   c
+|-- - d
+'-- + e
+`, result)
+}
+
+func TestDiff_Key(t *testing.T) {
+	before := NewTree()
+	before.AddBranch("Root").AddBranch("Leaf")
+	after := NewTree()
+	after.AddBranch("ROOT").AddBranch("leaf")
+
+	assert.True(t, Diff(before, after).HasChanges())
+
+	diff := Diff(before, after, WithDiffKey(func(branch *Tree) string {
+		return strings.ToLower(branch.Label)
+	}))
+	assert.False(t, diff.HasChanges())
+	assert.Equal(t, "  ROOT (1 unchanged)\n", diff.String())
+}
+
+func TestDiff_Identical(t *testing.T) {
+	tree := NewTree()
+	app := tree.AddBranch("app")
+	app.AddBranch("config").AddBranches("db.yml", "cache.yml")
+	app.AddBranch("lib").AddBranches("util.go", "log.go")
+	app.AddBranch("README")
+
+	diff := Diff(tree, tree)
+	assert.False(t, diff.HasChanges())
+	assert.Equal(t, "  app (7 unchanged)\n", diff.Print())
+}
+
+func ExampleDiff() {
+	before := NewTree()
+	deps := before.AddBranch("my-app")
+	deps.AddBranch("http-lib v1.2").AddBranch("json-lib v2.0")
+	deps.AddBranch("log-lib v0.9")
+
+	after := NewTree()
+	deps = after.AddBranch("my-app")
+	deps.AddBranch("http-lib v1.2").AddBranch("json-lib v2.1")
+	deps.AddBranch("log-lib v0.9")
+
+	fmt.Print(Diff(before, after).Print())
+	// Output:
+	//   my-app
+	// ├──   http-lib v1.2
+	// │   ├── - json-lib v2.0
+	// │   ╰── + json-lib v2.1
+	// ╰──   log-lib v0.9
+}
@@ -66,4 +66,33 @@
 //        (A) red
 //        (B) green
 //        (C) blue
+//
+// Layouts
+//
+// By default the tree grows downward and to the right. Other layouts can be chosen by passing
+// options to PrintStyle()
+//    tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout))
+// Prints the tree as an org chart
+//                      Monitors
+//              ╭──────────┴───────────╮
+//          Monocrome                Color
+//        ╭─────┴──────╮         ╭─────┼─────╮
+//    Old School  Contemporary  red  green  blue
+// Or with the root on the left and the branches fanning out to the right
+//    tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout))
+// The indented layout can also be printed upside down, with the root at the bottom
+//    tree.PrintStyle(BoxStyle, WithInverted())
+// Or mirrored, with the scaffold on the right and the labels right aligned
+//    tree.PrintStyle(BoxStyle, WithMirrored())
+// Layouts that do not fit into the terminal (see WithMaxWidth) fall back to the indented layout
+//
+// Comparing Trees
+//
+// Diff(before, after) compares two trees and returns a TreeDiff that can be printed in any style
+// with a marker in front of each changed branch
+//      Monitors
+//    ├──   Monocrome
+//    │   ├── - Old School
+//    │   ╰── + Retro
+//    ╰──   Color (3 unchanged)
 package printtree
@@ -0,0 +1,153 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIndexOutOfRange is returned when a branch index does not exist in the tree
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// ErrNoParent is returned when a branch that has no parent is detached or replaced
+var ErrNoParent = errors.New("branch has no parent")
+
+// ErrMoveIntoSubtree is returned when a branch would become a branch of itself or of one of its
+// own branches
+var ErrMoveIntoSubtree = errors.New("move into own subtree")
+
+// ErrNilTree is returned when a branch is moved into, or replaced with, a nil tree
+var ErrNilTree = errors.New("nil tree")
+
+// InsertBranch creates a new branch Tree at the given index of the branch list and returns that
+// branch. An index equal to the number of branches adds the branch at the end
+func (tree *Tree) InsertBranch(at int, branchName string) (*Tree, error) {
+	if at < 0 || at > len(tree.Branches) {
+		return nil, fmt.Errorf("%w: %d", ErrIndexOutOfRange, at)
+	}
+	branch := &Tree{Label: branchName, parent: tree}
+	tree.insert(at, branch)
+	return branch, nil
+}
+
+// RemoveBranch removes the branch at the given index from this tree and returns it. The removed
+// branch (and all its branches) is left intact so it can be added elsewhere
+func (tree *Tree) RemoveBranch(at int) (*Tree, error) {
+	if at < 0 || at >= len(tree.Branches) {
+		return nil, fmt.Errorf("%w: %d", ErrIndexOutOfRange, at)
+	}
+	branch := tree.Branches[at]
+	tree.Branches = append(tree.Branches[:at], tree.Branches[at+1:]...)
+	if branch.parent == tree {
+		branch.parent = nil
+	}
+	return branch, nil
+}
+
+// Detach removes this branch from its parent, making it the root of a tree of its own. A branch
+// that was grafted into several trees is only removed from the tree it was grafted into last
+func (tree *Tree) Detach() error {
+	if tree.parent == nil {
+		return ErrNoParent
+	}
+	index := tree.parent.indexOf(tree)
+	if index < 0 {
+		// the branch was removed from the parent by hand
+		tree.parent = nil
+		return nil
+	}
+	_, err := tree.parent.RemoveBranch(index)
+	return err
+}
+
+// MoveTo moves this branch, with all its branches, from its parent to the given index of the
+// branch list of the new parent. The index is the position of the branch after the move, so an
+// index equal to the number of branches of the new parent (not counting this branch) adds it at
+// the end. A branch can not be moved into itself or into any of its own branches
+func (tree *Tree) MoveTo(newParent *Tree, index int) error {
+	if newParent == nil {
+		return ErrNilTree
+	}
+	for ancestor := newParent; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == tree {
+			return ErrMoveIntoSubtree
+		}
+	}
+
+	size := len(newParent.Branches)
+	if tree.parent == newParent && newParent.indexOf(tree) >= 0 {
+		size--
+	}
+	if index < 0 || index > size {
+		return fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+	}
+
+	if tree.parent != nil {
+		if err := tree.Detach(); err != nil {
+			return err
+		}
+	}
+	newParent.insert(index, tree)
+	tree.parent = newParent
+	return nil
+}
+
+// ReplaceWith puts the other tree in the place of this branch in its parent. The other tree is
+// detached from its own parent first, and this branch is left without a parent. The other tree
+// can not be this branch's parent or any of its ancestors
+func (tree *Tree) ReplaceWith(other *Tree) error {
+	if other == nil {
+		return ErrNilTree
+	}
+	parent := tree.parent
+	if parent == nil || parent.indexOf(tree) < 0 {
+		// the branch may have been removed from the parent by hand
+		return ErrNoParent
+	}
+	for ancestor := parent; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == other {
+			return ErrMoveIntoSubtree
+		}
+	}
+	if other == tree {
+		return nil
+	}
+
+	if other.parent != nil {
+		if err := other.Detach(); err != nil {
+			return err
+		}
+	}
+	// detaching the other tree moves this branch up if they were both branches of the parent
+	parent.Branches[parent.indexOf(tree)] = other
+	other.parent = parent
+	tree.parent = nil
+	return nil
+}
+
+// Swap exchanges the positions of two branches of this tree
+func (tree *Tree) Swap(i, j int) error {
+	for _, index := range []int{i, j} {
+		if index < 0 || index >= len(tree.Branches) {
+			return fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+		}
+	}
+	tree.Branches[i], tree.Branches[j] = tree.Branches[j], tree.Branches[i]
+	return nil
+}
+
+// insert puts the branch at the given index of the branch list
+func (tree *Tree) insert(at int, branch *Tree) {
+	tree.Branches = append(tree.Branches, nil)
+	copy(tree.Branches[at+1:], tree.Branches[at:])
+	tree.Branches[at] = branch
+}
+
+// indexOf returns the index of the branch in the branch list, or -1 if it is not there
+func (tree *Tree) indexOf(branch *Tree) int {
+	for index := range tree.Branches {
+		if tree.Branches[index] == branch {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,174 @@
+package printtree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertBranch(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddBranches("a1", "a2")
+	root.AddBranches("b", "c")
+
+	first, err := root.InsertBranch(0, "first")
+	assert.NoError(t, err)
+	assert.Same(t, root, first.Parent())
+	_, err = root.InsertBranch(2, "middle")
+	assert.NoError(t, err)
+	_, err = root.InsertBranch(5, "last")
+	assert.NoError(t, err)
+
+	_, err = root.InsertBranch(7, "too far")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.EqualError(t, err, "index out of range: 7")
+	_, err = root.InsertBranch(-1, "negative")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Equal(t, `root
+├── first
+├── a
+│   ├── a1
+│   ╰── a2
+├── middle
+├── b
+├── c
+╰── last
+`, tree.Print())
+}
+
+func TestRemoveBranch(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddBranches("a1", "a2")
+	root.AddBranches("b", "c")
+
+	a, err := root.RemoveBranch(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", a.Label)
+	assert.Nil(t, a.Parent())
+	assert.Len(t, a.Branches, 2)
+
+	_, err = root.RemoveBranch(2)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Equal(t, `root
+├── b
+╰── c
+`, tree.Print())
+}
+
+func TestDetach(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("a1")
+	a2 := a.AddBranch("a2")
+	root.AddBranches("b", "c")
+
+	assert.NoError(t, a2.Detach())
+	assert.Nil(t, a2.Parent())
+	assert.Equal(t, []string{"a2"}, a2.Path())
+	assert.True(t, errors.Is(a2.Detach(), ErrNoParent))
+
+	assert.Equal(t, `root
+├── a
+│   ╰── a1
+├── b
+╰── c
+`, tree.Print())
+}
+
+func TestMoveTo(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranches("a1", "a2")
+	b := root.AddBranch("b")
+	c := root.AddBranch("c")
+
+	// move to another parent
+	assert.NoError(t, a.MoveTo(c, 0))
+	assert.Same(t, c, a.Parent())
+	assert.Equal(t, []string{"root", "c", "a", "a1"}, a.Branches[0].Path())
+
+	// move within the same parent
+	assert.NoError(t, b.MoveTo(root, 1))
+
+	assert.Equal(t, `root
+├── c
+│   ╰── a
+│       ├── a1
+│       ╰── a2
+╰── b
+`, tree.Print())
+
+	// invalid moves leave the tree alone
+	assert.True(t, errors.Is(c.MoveTo(a.Branches[0], 0), ErrMoveIntoSubtree))
+	assert.True(t, errors.Is(c.MoveTo(c, 0), ErrMoveIntoSubtree))
+	assert.True(t, errors.Is(b.MoveTo(root, 2), ErrIndexOutOfRange))
+	assert.True(t, errors.Is(b.MoveTo(c, 3), ErrIndexOutOfRange))
+	assert.True(t, errors.Is(b.MoveTo(nil, 0), ErrNilTree))
+	assert.Same(t, root, b.Parent())
+	assert.Len(t, root.Branches, 2)
+}
+
+func TestReplaceWith(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a1 := a.AddBranch("a1")
+	a.AddBranch("a2")
+	b := root.AddBranch("b")
+	c := root.AddBranch("c")
+
+	replacement := NewTree().AddBranch("replacement")
+	assert.NoError(t, b.ReplaceWith(replacement))
+	assert.Same(t, root, replacement.Parent())
+	assert.Nil(t, b.Parent())
+
+	// a branch from elsewhere in the tree is moved
+	assert.NoError(t, replacement.ReplaceWith(a1))
+
+	assert.Equal(t, `root
+├── a
+│   ╰── a2
+├── a1
+╰── c
+`, tree.Print())
+
+	assert.True(t, errors.Is(a.Branches[0].ReplaceWith(root), ErrMoveIntoSubtree))
+	assert.True(t, errors.Is(b.ReplaceWith(a), ErrNoParent))
+	assert.True(t, errors.Is(a.ReplaceWith(nil), ErrNilTree))
+
+	// a branch that was removed from its parent by hand is not replaced, and the other tree is
+	// left where it was
+	root.Branches = root.Branches[:2]
+	assert.True(t, errors.Is(c.ReplaceWith(a), ErrNoParent))
+	assert.Same(t, root, a.Parent())
+	assert.Equal(t, `root
+├── a
+│   ╰── a2
+╰── a1
+`, tree.Print())
+}
+
+func TestSwap(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("a").AddBranches("a1", "a2")
+	root.AddBranches("b", "c")
+
+	assert.NoError(t, root.Swap(0, 2))
+	assert.True(t, errors.Is(root.Swap(0, 3), ErrIndexOutOfRange))
+
+	assert.Equal(t, `root
+├── c
+├── b
+╰── a
+    ├── a1
+    ╰── a2
+`, tree.Print())
+}
@@ -39,6 +39,8 @@ func addFiles(tree *Tree, path string) error {
 
 		// add the file/dir as a child branch of the tree
 		child := tree.AddBranch(file.Name())
+		// keep the directory entry, so FileIcon can tell directories from files
+		child.Payload = file
 		if file.IsDir() {
 			// recur into directories
 			if err := addFiles(child, filepath.Join(path, file.Name())); err != nil {
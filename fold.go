@@ -0,0 +1,63 @@
+package printtree
+
+import (
+	"fmt"
+)
+
+// foldMarkers are the markers printed in front of branches that can be collapsed or expanded
+type foldMarkers struct {
+	collapsed string
+	expanded  string
+	leaf      string // for branches that have no branches, to line up with the others
+}
+
+var (
+	boxFoldMarkers   = foldMarkers{"▸ ", "▾ ", "  "}
+	asciiFoldMarkers = foldMarkers{"+ ", "- ", "  "}
+)
+
+// foldLines adds the number of hidden branches and the fold marker to the lines of the label of
+// the branch, if those options are set
+func (v *visitor) foldLines(branch *Tree, lines []string) []string {
+	if v.config.hiddenCount && branch.Collapsed {
+		if hidden := branch.Count(); hidden > 0 {
+			lines[0] += fmt.Sprintf(" (%d hidden)", hidden)
+		}
+	}
+	if !v.config.foldMarkers {
+		return lines
+	}
+
+	marker := v.markers.leaf
+	switch {
+	case len(branch.Branches) == 0:
+	case branch.Collapsed:
+		marker = v.markers.collapsed
+	default:
+		marker = v.markers.expanded
+	}
+	for index := range lines {
+		if index == 0 {
+			lines[index] = marker + lines[index]
+		} else {
+			lines[index] = v.markers.leaf + lines[index]
+		}
+	}
+	return lines
+}
+
+// CollapseAll collapses every branch below this tree that has branches of its own, so that only
+// the branches of this tree are printed
+func (tree *Tree) CollapseAll() {
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		branch.Collapsed = len(branch.Branches) > 0
+	})
+}
+
+// ExpandAll expands this tree and every branch below it
+func (tree *Tree) ExpandAll() {
+	tree.Collapsed = false
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		branch.Collapsed = false
+	})
+}
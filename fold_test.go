@@ -0,0 +1,143 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapsed(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+	tree.AddBranch("README.md")
+
+	// the branches of collapsed branches are hidden, with or without markers
+	assert.Equal(t, `src
+├── main.go
+╰── util
+README.md
+`, tree.Print())
+}
+
+func TestWithFoldMarkers(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, `▾ src
+├──   main.go
+╰── ▸ util
+  README.md
+`, tree.PrintStyle(BoxStyle, WithFoldMarkers()))
+
+	assert.Equal(t, `- .
+|-- - src
+|   |--   main.go
+|   '-- + util
+'--   README.md
+`, tree.PrintStyle(ASCIIStyle, WithFoldMarkers(), WithVisibleRoot()))
+}
+
+func TestWithHiddenCount(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, `src
+├── main.go
+╰── util (2 hidden)
+README.md
+`, tree.PrintStyle(BoxStyle, WithHiddenCount()))
+
+	// a collapsed root hides everything
+	tree.Collapsed = true
+	assert.Equal(t, `▸ . (6 hidden)
+`, tree.PrintStyle(BoxStyle, WithFoldMarkers(), WithHiddenCount(), WithVisibleRoot()))
+}
+
+func TestWithFoldMarkers_MultiLine(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("first\nsecond").AddBranch("leaf")
+	assert.Equal(t, `▾ first
+  second
+╰──   leaf
+`, tree.PrintStyle(BoxStyle, WithFoldMarkers()))
+}
+
+func TestWithFoldMarkers_TopDown(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+
+	assert.Equal(t, `      ▾ src
+    ╭───┴────╮
+  main.go  ▸ util
+`, tree.PrintStyle(BoxStyle, WithFoldMarkers(), WithLayout(TopDownLayout), WithMaxWidth(0)))
+}
+
+func TestCollapseAll(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+	readme := tree.AddBranch("README.md")
+
+	tree.CollapseAll()
+	assert.Equal(t, `src
+README.md
+`, tree.Print())
+	assert.True(t, util.Collapsed)
+	assert.False(t, readme.Collapsed, "leaves are not collapsed")
+
+	tree.ExpandAll()
+	assert.Equal(t, `src
+├── main.go
+╰── util
+    ├── a.go
+    ╰── b.go
+README.md
+`, tree.Print())
+}
+
+func TestCollapsed_Clone(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	util.Collapsed = true
+
+	clone := tree.Clone()
+	assert.True(t, clone.Branches[0].Branches[1].Collapsed)
+}
+
+func ExampleWithFoldMarkers() {
+	tree := NewTree()
+	docs := tree.AddBranch("docs")
+	docs.AddBranches("intro.md", "usage.md")
+	docs.Collapsed = true
+	tree.AddBranch("src").AddBranch("main.go")
+	fmt.Print(tree.PrintStyle(BoxStyle, WithFoldMarkers(), WithHiddenCount()))
+	// Output:
+	// ▸ docs (2 hidden)
+	// ▾ src
+	// ╰──   main.go
+}
@@ -0,0 +1,244 @@
+package printtree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter, so trees can be printed with the fmt verbs
+//   %s   the tree indented with whitespace, like String
+//   %v   the tree in BoxStyle, like Print
+//   %+v  the tree in BoxStyle, with the depth and count of each branch that has branches
+//   %#v  Go source that builds the tree with NewTree and AddBranch
+//   %q   the tree indented with whitespace, as a quoted Go string
+// The width is the maximum width of each line: longer lines are cut short with "…". The
+// precision is the number of levels of branches that are printed, so "%.1v" prints only the top
+// level branches. For example
+//   fmt.Printf("%+60.2v", tree)
+func (tree *Tree) Format(f fmt.State, verb rune) {
+	if tree == nil {
+		fmt.Fprint(f, "<nil>")
+		return
+	}
+
+	switch verb {
+	case 's', 'v':
+	case 'q':
+		fmt.Fprintf(f, "%q", tree.String())
+		return
+	default:
+		fmt.Fprintf(f, "%%!%c(*printtree.Tree=%s)", verb, tree.Label)
+		return
+	}
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, tree.goSource())
+		return
+	}
+
+	printed := tree
+	if depth, ok := f.Precision(); ok {
+		printed = printed.Clone()
+		if depth <= 0 {
+			// no levels at all
+			printed.Branches = nil
+		}
+		printed.collapseBelow(depth)
+	}
+	if verb == 'v' && f.Flag('+') {
+		if printed == tree {
+			printed = printed.Clone()
+		}
+		printed.annotateShape()
+	}
+
+	var text string
+	if verb == 's' {
+		text = printed.String()
+	} else {
+		text = printed.Print()
+	}
+	if width, ok := f.Width(); ok {
+		text = truncateLines(text, width)
+	}
+	fmt.Fprint(f, text)
+}
+
+// collapseBelow collapses the branches of the tree that are depth levels below it, so that
+// their branches are not printed
+func (tree *Tree) collapseBelow(depth int) {
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, branchDepth int) {
+		if branchDepth >= depth {
+			branch.Collapsed = true
+		}
+	})
+}
+
+// annotateShape adds the depth and the number of branches to the label of every branch that
+// has branches, like "src (depth 2, 4 branches)"
+func (tree *Tree) annotateShape() {
+	annotated := make(map[*Tree]bool)
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		if annotated[branch] || len(branch.Branches) == 0 {
+			return
+		}
+		annotated[branch] = true
+		lines := strings.SplitN(branch.Label, "\n", 2)
+		lines[0] += fmt.Sprintf(" (depth %d, %d branches)", branch.Depth(), branch.Count())
+		branch.Label = strings.Join(lines, "\n")
+	})
+}
+
+// truncateLines cuts each line of the text that is wider than the width short, ending it with
+// "…". Escape sequences (such as colors and hyperlinks) are kept, so none are left open
+func truncateLines(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+		if textWidth(line) <= width {
+			continue
+		}
+		buf := strings.Builder{}
+		column := 0
+		forEachCluster(line, func(cluster string, clusterWidth int) {
+			switch {
+			case escapeLength(cluster) > 0:
+				buf.WriteString(cluster)
+			case column < 0:
+				// the line has already been cut
+			case column+clusterWidth < width:
+				buf.WriteString(cluster)
+				column += clusterWidth
+			default:
+				buf.WriteString("…")
+				column = -1
+			}
+		})
+		lines[index] = buf.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// goSource returns Go source that builds a copy of the tree. Branches that have branches of
+// their own, or fields other than a label, are assigned to variables. A branch that appears
+// more than once (see AddTreeAsBranch) is added again with AddTreeAsBranch, so the copy has the
+// same shape. Payloads are not part of the source
+func (tree *Tree) goSource() string {
+	source := &goSource{vars: map[*Tree]string{tree: "tree"}, parents: make(map[*Tree]int)}
+	source.countParents(tree, map[*Tree]bool{tree: true})
+	source.line("tree := printtree.NewTree()")
+	source.fields("tree", tree, true)
+	source.branches(tree, "tree", ancestry{tree: true})
+	return source.String()
+}
+
+// goSource collects the lines of Go source that build a tree
+type goSource struct {
+	strings.Builder
+	vars    map[*Tree]string // the variables that branches have been assigned to
+	parents map[*Tree]int    // the number of branch lists that each branch appears in
+	count   int              // the number of variables declared, which numbers the next one
+}
+
+// countParents counts, for every branch below the tree, the number of branch lists it appears in
+func (source *goSource) countParents(tree *Tree, visited map[*Tree]bool) {
+	for _, branch := range tree.Branches {
+		source.parents[branch]++
+		if !visited[branch] {
+			visited[branch] = true
+			source.countParents(branch, visited)
+		}
+	}
+}
+
+// needsVar returns true if the source refers to the branch after adding it, so it has to be
+// assigned to a variable: to set its fields, to add its branches, or to add it again elsewhere
+func (source *goSource) needsVar(branch *Tree, ancestors ancestry) bool {
+	if branch.Collapsed || branch.URL != "" || branch.Icon != "" {
+		return true
+	}
+	if branch.Label != "" && source.parents[branch] > 1 {
+		return true
+	}
+	for _, child := range branch.Branches {
+		// branches without a label that loop back are the only ones that are left out
+		if child.Label != "" || (child != branch && !ancestors[child]) {
+			return true
+		}
+	}
+	return false
+}
+
+// line adds a line of source
+func (source *goSource) line(format string, a ...interface{}) {
+	fmt.Fprintf(source, format+"\n", a...)
+}
+
+// fields adds the assignments of the fields of the branch, other than its label and branches.
+// The label is only assigned for the tree itself
+func (source *goSource) fields(name string, branch *Tree, label bool) {
+	if label && branch.Label != "" {
+		source.line("%s.Label = %s", name, strconv.Quote(branch.Label))
+	}
+	if branch.Collapsed {
+		source.line("%s.Collapsed = true", name)
+	}
+	if branch.URL != "" {
+		source.line("%s.URL = %s", name, strconv.Quote(branch.URL))
+	}
+	if branch.Icon != "" {
+		source.line("%s.Icon = %s", name, strconv.Quote(branch.Icon))
+	}
+}
+
+// branches adds the source that adds the branches of the tree, which is assigned to the
+// variable called parent. Runs of plain leaves are added with a single call to AddBranches
+func (source *goSource) branches(tree *Tree, parent string, ancestors ancestry) {
+	var leaves []string
+	flush := func() {
+		switch len(leaves) {
+		case 0:
+		case 1:
+			source.line("%s.AddBranch(%s)", parent, leaves[0])
+		default:
+			source.line("%s.AddBranches(%s)", parent, strings.Join(leaves, ", "))
+		}
+		leaves = nil
+	}
+
+	for _, branch := range tree.Branches {
+		if name, ok := source.vars[branch]; ok && branch.Label != "" {
+			// a shared branch, or one that loops back to an ancestor
+			flush()
+			source.line("%s.AddTreeAsBranch(%s)", parent, name)
+			continue
+		}
+		if len(branch.Branches) == 0 && !branch.Collapsed && branch.URL == "" && branch.Icon == "" {
+			leaves = append(leaves, strconv.Quote(branch.Label))
+			continue
+		}
+		flush()
+		if ancestors[branch] {
+			// loops back to an ancestor without a label, which can not be grafted
+			continue
+		}
+		if !source.needsVar(branch, ancestors) {
+			source.line("%s.AddBranch(%s)", parent, strconv.Quote(branch.Label))
+			continue
+		}
+
+		// a branch without a label that appears more than once is assigned to a new variable
+		// each time, so the variables are numbered by the count rather than by the map
+		source.count++
+		name := fmt.Sprintf("branch%d", source.count)
+		source.vars[branch] = name
+		source.line("%s := %s.AddBranch(%s)", name, parent, strconv.Quote(branch.Label))
+		source.fields(name, branch, false)
+		ancestors[branch] = true
+		source.branches(branch, name, ancestors)
+		delete(ancestors, branch)
+	}
+	flush()
+}
@@ -0,0 +1,248 @@
+package printtree
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, tree.Print(), fmt.Sprintf("%v", tree))
+	assert.Equal(t, tree.String(), fmt.Sprintf("%s", tree))
+	assert.Equal(t, fmt.Sprintf("%q", tree.String()), fmt.Sprintf("%q", tree))
+	assert.Equal(t, "%!d(*printtree.Tree=)", fmt.Sprintf("%d", tree))
+
+	var nilTree *Tree
+	assert.Equal(t, "<nil>", fmt.Sprintf("%v", nilTree))
+}
+
+func TestFormat_Plus(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, `src (depth 2, 4 branches)
+├── main.go
+╰── util (depth 1, 2 branches)
+    ├── a.go
+    ╰── b.go
+README.md
+`, fmt.Sprintf("%+v", tree))
+}
+
+func TestFormat_Precision(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, `src
+README.md
+`, fmt.Sprintf("%.1v", tree))
+	assert.Equal(t, `src
+├── main.go
+╰── util
+README.md
+`, fmt.Sprintf("%.2v", tree))
+	assert.Equal(t, "", fmt.Sprintf("%.0v", tree))
+	assert.Equal(t, `src (depth 2, 4 branches)
+README.md
+`, fmt.Sprintf("%+.1v", tree))
+
+	// the tree itself is left alone
+	assert.False(t, src.Collapsed)
+}
+
+func TestFormat_Width(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("util").AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+
+	assert.Equal(t, `src
+├── main…
+╰── util
+    ├── …
+    ╰── …
+README.md
+`, fmt.Sprintf("%9v", tree))
+	assert.Equal(t, "src\n    mai…\n    util\n       …\n       …\nREADME.…\n", fmt.Sprintf("%8s", tree))
+
+	// escape sequences are kept when lines are cut
+	assert.Equal(t, "\x1b[31mred…\x1b[0m", truncateLines("\x1b[31mred text\x1b[0m", 4))
+	assert.Equal(t, "wide: 世…", truncateLines("wide: 世界", 9))
+}
+
+func TestFormat_GoSource(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	util := src.AddBranch("util")
+	util.AddBranches("a.go", "b.go")
+	tree.AddBranch("README.md")
+	tree.Label = "project"
+	util.Collapsed = true
+	util.URL = "https://example.com/util"
+	tree.AddBranch("docs").Icon = "📁"
+	tree.AddTreeAsBranch(util)
+
+	assert.Equal(t, `tree := printtree.NewTree()
+tree.Label = "project"
+branch1 := tree.AddBranch("src")
+branch1.AddBranch("main.go")
+branch2 := branch1.AddBranch("util")
+branch2.Collapsed = true
+branch2.URL = "https://example.com/util"
+branch2.AddBranches("a.go", "b.go")
+tree.AddBranch("README.md")
+branch3 := tree.AddBranch("docs")
+branch3.Icon = "📁"
+tree.AddTreeAsBranch(branch2)
+`, fmt.Sprintf("%#v", tree))
+}
+
+func TestFormat_GoSourceCycle(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+
+	assert.Equal(t, `tree := printtree.NewTree()
+branch1 := tree.AddBranch("root")
+branch2 := branch1.AddBranch("a")
+branch2.AddBranch("leaf")
+branch3 := branch2.AddBranch("b")
+branch3.AddTreeAsBranch(branch1)
+`, fmt.Sprintf("%#v", tree))
+}
+
+func TestFormat_GoSourceUnlabeledCycle(t *testing.T) {
+	tree := NewTree()
+	unlabeled := tree.AddBranch("")
+	a := unlabeled.AddBranch("a")
+	// AddTreeAsBranch would graft the branches of a tree without a label, so loop back by hand
+	a.Branches = append(a.Branches, unlabeled)
+
+	// the loop back to the branch without a label is left out, so "a" is not assigned to a
+	// variable that nothing uses
+	assert.Equal(t, `tree := printtree.NewTree()
+branch1 := tree.AddBranch("")
+branch1.AddBranch("a")
+`, fmt.Sprintf("%#v", tree))
+}
+
+// printtreeStub declares the part of the API that the Go source of a tree uses, so the source
+// can be type checked without compiling this package
+const printtreeStub = `package printtree
+type Tree struct {
+	Label     string
+	Collapsed bool
+	URL       string
+	Icon      string
+}
+func NewTree() *Tree { return nil }
+func (tree *Tree) AddBranch(branchName string) *Tree { return nil }
+func (tree *Tree) AddBranches(branchNames ...string) []*Tree { return nil }
+func (tree *Tree) AddTreeAsBranch(other *Tree) {}
+`
+
+// stubImporter imports the printtree stub, and the standard library as usual
+type stubImporter struct {
+	fset *token.FileSet
+}
+
+func (stub stubImporter) Import(path string) (*types.Package, error) {
+	if path != "printtree" {
+		return importer.Default().Import(path)
+	}
+	file, err := parser.ParseFile(stub.fset, "printtree.go", printtreeStub, 0)
+	if err != nil {
+		return nil, err
+	}
+	return (&types.Config{}).Check(path, stub.fset, []*ast.File{file}, nil)
+}
+
+// typeCheck returns the first error in the Go source of the tree, or nil if it compiles
+func typeCheck(tree *Tree) error {
+	fset := token.NewFileSet()
+	source := fmt.Sprintf("package main\n\nimport \"printtree\"\n\nfunc main() {\n%#v_ = tree\n}\n", tree)
+	file, err := parser.ParseFile(fset, "main.go", source, 0)
+	if err != nil {
+		return err
+	}
+	config := &types.Config{Importer: stubImporter{fset: fset}}
+	_, err = config.Check("main", fset, []*ast.File{file}, nil)
+	return err
+}
+
+func TestFormat_GoSourceCompiles(t *testing.T) {
+	// a collapsed branch without a label, under two parents, is assigned to a variable twice
+	tree := NewTree()
+	unlabeled := &Tree{Collapsed: true}
+	unlabeled.AddBranch("leaf")
+	tree.AddBranch("a").Branches = []*Tree{unlabeled}
+	tree.AddBranch("b").Branches = []*Tree{unlabeled}
+	tree.AddBranch("c").AddBranch("d").Collapsed = true
+
+	assert.Equal(t, `tree := printtree.NewTree()
+branch1 := tree.AddBranch("a")
+branch2 := branch1.AddBranch("")
+branch2.Collapsed = true
+branch2.AddBranch("leaf")
+branch3 := tree.AddBranch("b")
+branch4 := branch3.AddBranch("")
+branch4.Collapsed = true
+branch4.AddBranch("leaf")
+branch5 := tree.AddBranch("c")
+branch6 := branch5.AddBranch("d")
+branch6.Collapsed = true
+`, fmt.Sprintf("%#v", tree))
+	assert.NoError(t, typeCheck(tree))
+
+	// the other shapes of the tests above compile too
+	cyclic := NewTree()
+	root := cyclic.AddBranch("root")
+	root.AddBranch("a").AddBranch("b").AddTreeAsBranch(root)
+	assert.NoError(t, typeCheck(cyclic))
+
+	shared := NewTree()
+	util := shared.AddBranch("src").AddBranch("util")
+	util.URL = "https://example.com/util"
+	util.AddBranches("a.go", "b.go")
+	shared.AddTreeAsBranch(util)
+	assert.NoError(t, typeCheck(shared))
+}
+
+func ExampleTree_Format() {
+	tree := NewTree()
+	tree.AddBranch("src").AddBranches("main.go", "util.go")
+	tree.AddBranch("README.md")
+	fmt.Printf("%+v\n%#v", tree, tree)
+	// Output:
+	// src (depth 1, 2 branches)
+	// ├── main.go
+	// ╰── util.go
+	// README.md
+	//
+	// tree := printtree.NewTree()
+	// branch1 := tree.AddBranch("src")
+	// branch1.AddBranches("main.go", "util.go")
+	// tree.AddBranch("README.md")
+}
@@ -0,0 +1,135 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// horizontalBlock recursively renders this tree, and all its branches, with the root on the
+// left and the branches fanning out to the right. Each label is vertically centered on the
+// lines of its branches
+func (tree *Tree) horizontalBlock(glyphs lineGlyphs, tabWidth int, v *visitor) chartBlock {
+	labelLines := splitLabel(tree.Label, tabWidth)
+	labelWidth := 0
+	for _, line := range labelLines {
+		if lineWidth := textWidth(line); lineWidth > labelWidth {
+			labelWidth = lineWidth
+		}
+	}
+	labelAnchor := (len(labelLines) - 1) / 2
+
+	if len(tree.Branches) == 0 {
+		block := chartBlock{
+			lines:  make([]string, 0, len(labelLines)),
+			width:  labelWidth,
+			anchor: labelAnchor,
+		}
+		for _, line := range labelLines {
+			block.lines = append(block.lines, padRight(line, labelWidth))
+		}
+		return block
+	}
+
+	// stack the branches on top of each other, remembering where each one is anchored
+	children := make([]chartBlock, 0, len(tree.Branches))
+	anchors := make([]int, 0, len(tree.Branches))
+	childrenHeight := 0
+	childrenWidth := 0
+	for _, branch := range tree.Branches {
+		branch = v.enter(branch)
+		child := branch.horizontalBlock(glyphs, tabWidth, v)
+		v.leave(branch)
+		children = append(children, child)
+		anchors = append(anchors, childrenHeight+child.anchor)
+		childrenHeight += len(child.lines)
+		if child.width > childrenWidth {
+			childrenWidth = child.width
+		}
+	}
+
+	// center the parent on the first and last branch. if the label is too tall to be centered
+	// there, shift the branches down to make room
+	anchor := (anchors[0] + anchors[len(anchors)-1]) / 2
+	labelStart := anchor - labelAnchor
+	shift := 0
+	if labelStart < 0 {
+		shift = -labelStart
+		labelStart = 0
+		anchor += shift
+	}
+	height := childrenHeight + shift
+	if labelStart+len(labelLines) > height {
+		height = labelStart + len(labelLines)
+	}
+
+	// flatten the branches into a single column of lines, and mark which lines they attach to
+	childLines := make([]string, height)
+	childAnchors := make(map[int]bool, len(anchors))
+	line := shift
+	for index, child := range children {
+		for _, childLine := range child.lines {
+			childLines[line] = childLine
+			line++
+		}
+		childAnchors[anchors[index]+shift] = true
+	}
+
+	// each line is made up of the label, the link to the connector, the connector and the link
+	// to the branch, followed by the branch itself. for example "Label ─┬─ Branch"
+	first := anchors[0] + shift
+	last := anchors[len(anchors)-1] + shift
+	width := labelWidth + 5 + childrenWidth
+	block := chartBlock{
+		lines:  make([]string, 0, height),
+		width:  width,
+		anchor: anchor,
+	}
+	for line := 0; line < height; line++ {
+		label := ""
+		if line >= labelStart && line < labelStart+len(labelLines) {
+			label = labelLines[line-labelStart]
+		}
+		fill := " "
+		if line == anchor {
+			// extend the link all the way back to the end of the label
+			fill = glyphs.horizontal
+		}
+		parentLink := label + " " + strings.Repeat(fill, labelWidth-textWidth(label)+1)
+		branchLink := " "
+		if childAnchors[line] {
+			branchLink = glyphs.horizontal
+		}
+		connector := horizontalConnector(glyphs, line, anchor, first, last, childAnchors[line])
+
+		text := parentLink + connector + branchLink + " " + childLines[line]
+		block.lines = append(block.lines, padRight(text, width))
+	}
+
+	return block
+}
+
+// horizontalConnector returns the glyph on the given line of the vertical connector that joins
+// a parent (on the anchor line) to its branches (on the lines from first to last)
+func horizontalConnector(glyphs lineGlyphs, line int, anchor int, first int, last int, isBranch bool) string {
+	switch {
+	case first == last && line == first:
+		// a single branch continues straight on from its parent
+		return glyphs.horizontal
+	case line < first || line > last:
+		return " "
+	case line == first && line == anchor:
+		return glyphs.teeDown
+	case line == first:
+		return glyphs.topLeft
+	case line == last && line == anchor:
+		return glyphs.teeUp
+	case line == last:
+		return glyphs.bottomLeft
+	case isBranch && line == anchor:
+		return glyphs.cross
+	case isBranch:
+		return glyphs.teeRight
+	case line == anchor:
+		return glyphs.teeLeft
+	}
+	return glyphs.vertical
+}
@@ -0,0 +1,79 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeftToRightLayout(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("Monitors")
+	monoTree := root.AddBranch("Monochrome")
+	monoTree.AddBranch("Old School").AddBranches("black", "green")
+	root.AddBranch("Color").AddBranches("red", "green", "blue")
+
+	result := tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(0))
+	t.Logf("OUT :\n%s", result)
+	assert.Equal(t, `          ╭─ Monochrome ─── Old School ─┬─ black
+Monitors ─┤                             ╰─ green
+          │         ╭─ red
+          ╰─ Color ─┼─ green
+                    ╰─ blue
+`, result)
+
+	result = tree.PrintStyle(ASCIIStyle, WithLayout(LeftToRightLayout), WithMaxWidth(0))
+	assert.Equal(t, `          +- Monochrome --- Old School -+- black
+Monitors -+                             '- green
+          |         +- red
+          '- Color -+- green
+                    '- blue
+`, result)
+}
+
+func TestLeftToRightLayout_MultilineLabels(t *testing.T) {
+	tree := NewTree()
+	final := tree.AddBranch("Final")
+	final.AddBranch("Semi 1\n(Tuesday)").AddBranches("Team A", "Team B")
+	final.AddBranch("Semi 2").AddBranch("Team C\nwildcard")
+
+	result := tree.PrintStyle(BoxBoldStyle, WithLayout(LeftToRightLayout), WithMaxWidth(0))
+	t.Logf("OUT :\n%s", result)
+	assert.Equal(t, `       ┏━ Semi 1 ━━━━┳━ Team A
+Final ━┫  (Tuesday)  ┗━ Team B
+       ┗━ Semi 2 ━━━ Team C
+                     wildcard
+`, result)
+}
+
+func TestLeftToRightLayout_TallLabel(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("one\ntwo\nthree").AddBranches("a", "b")
+
+	result := tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(0))
+	assert.Equal(t, `one
+two ───┬─ a
+three  ╰─ b
+`, result)
+}
+
+func TestLeftToRightLayout_Fallback(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("1").AddBranches("alpha", "bravo")
+
+	assert.Equal(t, "1 ─┬─ alpha\n   ╰─ bravo\n", tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(11)))
+	assert.Equal(t, "1\n├── alpha\n╰── bravo\n", tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(10)))
+}
+
+func ExampleWithLayout_leftToRight() {
+	tree := NewTree()
+	root := tree.AddBranch("Is it raining?")
+	root.AddBranch("yes").AddBranch("take an umbrella")
+	root.AddBranch("no").AddBranches("is it sunny?", "stay inside")
+	fmt.Print(tree.PrintStyle(BoxStyle, WithLayout(LeftToRightLayout), WithMaxWidth(80)))
+	// Output:
+	// Is it raining? ─┬─ yes ─── take an umbrella
+	//                 ╰─ no ─┬─ is it sunny?
+	//                        ╰─ stay inside
+}
@@ -0,0 +1,72 @@
+package printtree
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Hyperlinks sets when the URLs of branches are printed as terminal hyperlinks
+type Hyperlinks int
+
+const (
+	// HyperlinksOff never prints hyperlinks. Labels are printed as plain text. This is the default
+	HyperlinksOff Hyperlinks = iota
+	// HyperlinksOn always prints hyperlinks
+	HyperlinksOn
+	// HyperlinksAuto prints hyperlinks if the environment shows a terminal that is known to
+	// support them. It can not tell whether the output is written to that terminal, so it is best
+	// used only when it is
+	HyperlinksAuto
+)
+
+// hyperlinkTerminals are the values of TERM_PROGRAM of terminals that support hyperlinks
+var hyperlinkTerminals = map[string]bool{
+	"iTerm.app": true,
+	"WezTerm":   true,
+	"vscode":    true,
+	"ghostty":   true,
+	"Hyper":     true,
+}
+
+// hyperlink returns the text as an OSC 8 hyperlink to the URL. The escape sequences take up no
+// columns, so the text is as wide as before
+func hyperlink(text string, url string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// hyperlinkLines turns each of the lines of a label into a hyperlink to the URL. Each line gets
+// a hyperlink of its own, so the scaffold between the lines is not part of the link
+func hyperlinkLines(lines []string, url string) []string {
+	for index, line := range lines {
+		if line != "" {
+			lines[index] = hyperlink(line, url)
+		}
+	}
+	return lines
+}
+
+// terminalHyperlinks returns true if the environment shows a terminal that supports hyperlinks.
+// FORCE_HYPERLINK overrides the detection: "0" turns hyperlinks off and any other value turns
+// them on
+func terminalHyperlinks() bool {
+	if force, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		return force != "0"
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case term == "dumb":
+		return false
+	case os.Getenv("WT_SESSION") != "", os.Getenv("KITTY_WINDOW_ID") != "", os.Getenv("DOMTERM") != "":
+		return true
+	case hyperlinkTerminals[os.Getenv("TERM_PROGRAM")]:
+		return true
+	case strings.HasPrefix(term, "xterm-kitty"), strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "alacritty"):
+		return true
+	}
+
+	// VTE based terminals (such as GNOME Terminal) support hyperlinks since 0.50
+	version, err := strconv.Atoi(os.Getenv("VTE_VERSION"))
+	return err == nil && version >= 5000
+}
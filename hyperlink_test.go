@@ -0,0 +1,122 @@
+package printtree
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withEnv sets the environment variable for the duration of fn
+func withEnv(name, value string, fn func()) {
+	old, ok := os.LookupEnv(name)
+	os.Setenv(name, value)
+	defer func() {
+		if ok {
+			os.Setenv(name, old)
+		} else {
+			os.Unsetenv(name)
+		}
+	}()
+	fn()
+}
+
+func TestWithHyperlinks(t *testing.T) {
+	tree := NewTree()
+	docs := tree.AddBranch("docs")
+	docs.URL = "file:///home/docs"
+	docs.AddBranch("README.md").URL = "file:///home/docs/README.md"
+	docs.AddBranch("notes")
+
+	assert.Equal(t, "\x1b]8;;file:///home/docs\x1b\\docs\x1b]8;;\x1b\\\n"+
+		"├── \x1b]8;;file:///home/docs/README.md\x1b\\README.md\x1b]8;;\x1b\\\n"+
+		"╰── notes\n", tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksOn)))
+
+	assert.Equal(t, `docs
+├── README.md
+╰── notes
+`, tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksOff)))
+}
+
+func TestWithHyperlinks_Auto(t *testing.T) {
+	tree := NewTree()
+	docs := tree.AddBranch("docs")
+	docs.URL = "file:///home/docs"
+	docs.AddBranch("README.md").URL = "file:///home/docs/README.md"
+	docs.AddBranch("notes")
+
+	withEnv("FORCE_HYPERLINK", "0", func() {
+		assert.NotContains(t, tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksAuto)), "\x1b]8")
+	})
+	withEnv("FORCE_HYPERLINK", "1", func() {
+		assert.Contains(t, tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksAuto)), hyperlink("docs", "file:///home/docs"))
+		// hyperlinks are opt-in, so the default output does not depend on the environment
+		assert.NotContains(t, tree.Print(), "\x1b]8")
+		assert.NotContains(t, fmt.Sprintf("%v", tree), "\x1b]8")
+	})
+}
+
+func TestWithHyperlinks_MultiLine(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("a")
+	branch := tree.AddBranch("first\nsecond")
+	branch.URL = "https://example.com"
+	branch.AddBranch("leaf")
+
+	// each line is a link of its own, and the fold marker is outside the link
+	assert.Equal(t, "  a\n"+
+		"▾ "+hyperlink("first", "https://example.com")+"\n"+
+		"  "+hyperlink("second", "https://example.com")+"\n"+
+		"╰──   leaf\n", tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksOn), WithFoldMarkers()))
+}
+
+func TestWithHyperlinks_Alignment(t *testing.T) {
+	tree := NewTree()
+	docs := tree.AddBranch("docs")
+	docs.URL = "file:///home/docs"
+	docs.AddBranch("README.md").URL = "file:///home/docs/README.md"
+	docs.AddBranch("notes")
+
+	// the escape sequences do not take up any room in the layout
+	plain := tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0), WithHyperlinks(HyperlinksOff))
+	linked := tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0), WithHyperlinks(HyperlinksOn))
+	assert.Equal(t, plain, stripHyperlinks(linked))
+
+	plain = tree.PrintStyle(BoxStyle, WithMirrored(), WithHyperlinks(HyperlinksOff))
+	linked = tree.PrintStyle(BoxStyle, WithMirrored(), WithHyperlinks(HyperlinksOn))
+	assert.Equal(t, plain, stripHyperlinks(linked))
+}
+
+func TestHyperlink_Width(t *testing.T) {
+	assert.Equal(t, 4, textWidth(hyperlink("docs", "file:///home/docs")))
+	assert.Equal(t, 4, textWidth("\x1b]8;;https://example.com\adocs\x1b]8;;\a"))
+	assert.Equal(t, 0, textWidth("\x1b]8;;unterminated"))
+}
+
+func TestHyperlink_Compact(t *testing.T) {
+	// branches with a URL are not merged into a chain
+	tree := NewTree()
+	a := tree.AddBranch("a")
+	a.URL = "https://example.com/a"
+	a.AddBranch("b").AddBranch("c")
+	tree.Compact("/")
+	assert.Equal(t, `a
+╰── b/c
+`, tree.PrintStyle(BoxStyle, WithHyperlinks(HyperlinksOff)))
+	assert.Equal(t, "https://example.com/a", tree.Clone().Branches[0].URL)
+}
+
+// stripHyperlinks removes the OSC 8 escape sequences from the text
+func stripHyperlinks(text string) string {
+	stripped := []byte{}
+	for len(text) > 0 {
+		if length := escapeLength(text); length > 0 {
+			text = text[length:]
+			continue
+		}
+		stripped = append(stripped, text[0])
+		text = text[1:]
+	}
+	return string(stripped)
+}
@@ -0,0 +1,105 @@
+package printtree
+
+import (
+	"path"
+	"strings"
+)
+
+// iconColumns is the number of columns that icons are padded to, so that labels line up whether
+// their icons are wide (like most emoji) or narrow
+const iconColumns = 2
+
+// IconFunc returns the icon that is printed in front of the label of a branch, or "" for none
+type IconFunc func(branch *Tree) string
+
+// Icons used by FileIcon for directories and for files with an extension that is not in
+// FileIcons
+const (
+	DirectoryIcon   = "📁"
+	DefaultFileIcon = "📄"
+)
+
+// FileIcons maps file extensions (with the leading dot, in lower case) and file names to the
+// icons that FileIcon prints for them. Entries can be added or changed to customize the icons
+var FileIcons = map[string]string{
+	".go":        "🐹",
+	".md":        "📝",
+	".txt":       "📝",
+	".json":      "🔧",
+	".yaml":      "🔧",
+	".yml":       "🔧",
+	".toml":      "🔧",
+	".xml":       "🔧",
+	".html":      "🌐",
+	".css":       "🎨",
+	".js":        "📜",
+	".ts":        "📜",
+	".py":        "🐍",
+	".rs":        "🦀",
+	".java":      "☕",
+	".rb":        "💎",
+	".sh":        "💲",
+	".png":       "🖼",
+	".jpg":       "🖼",
+	".jpeg":      "🖼",
+	".gif":       "🖼",
+	".svg":       "🖼",
+	".pdf":       "📕",
+	".zip":       "📦",
+	".tar":       "📦",
+	".gz":        "📦",
+	".lock":      "🔒",
+	"go.mod":     "🐹",
+	"go.sum":     "🔒",
+	"Makefile":   "🔨",
+	"Dockerfile": "🐳",
+	"LICENSE":    "📜",
+}
+
+// FileIcon is an IconFunc for trees of files, such as the ones built from a directory. Branches
+// that are directories get DirectoryIcon, and files get the icon of their name or extension in
+// FileIcons, or DefaultFileIcon if there is none. A branch is a directory if it has branches,
+// or if its Payload has an IsDir method (like os.FileInfo and fs.DirEntry) that returns true
+func FileIcon(branch *Tree) string {
+	if isDirectory(branch) {
+		return DirectoryIcon
+	}
+	name := path.Base(branch.Label)
+	if icon, ok := FileIcons[name]; ok {
+		return icon
+	}
+	if icon, ok := FileIcons[strings.ToLower(path.Ext(name))]; ok {
+		return icon
+	}
+	return DefaultFileIcon
+}
+
+// isDirectory returns true if the branch stands for a directory
+func isDirectory(branch *Tree) bool {
+	if entry, ok := branch.Payload.(interface{ IsDir() bool }); ok {
+		return entry.IsDir()
+	}
+	return len(branch.Branches) > 0
+}
+
+// icon returns the icon of the branch: its own Icon, or the one chosen by the IconFunc
+func (v *visitor) icon(branch *Tree) string {
+	if branch.Icon != "" || v.config.iconFunc == nil {
+		return branch.Icon
+	}
+	return v.config.iconFunc(branch)
+}
+
+// iconLines puts the icon in front of the first line of a label. The following lines are
+// indented to line up with the first
+func iconLines(lines []string, icon string) []string {
+	prefix := padRight(icon, iconColumns) + " "
+	for index := range lines {
+		if index == 0 {
+			lines[index] = prefix + lines[index]
+		} else {
+			lines[index] = strings.Repeat(" ", textWidth(prefix)) + lines[index]
+		}
+	}
+	return lines
+}
@@ -0,0 +1,146 @@
+package printtree
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIcon(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("notes\nmore")
+	tree.AddBranch("README.md")
+	tree.AddBranch("custom").Icon = "*"
+
+	// icons of branches are printed without an IconFunc, and padded to line up
+	assert.Equal(t, `src
+├── main.go
+╰── notes
+    more
+README.md
+*  custom
+`, tree.Print())
+}
+
+func TestWithIconFunc(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("notes\nmore")
+	tree.AddBranch("README.md")
+	tree.AddBranch("custom").Icon = "*"
+
+	assert.Equal(t, `📁 src
+├── 🐹 main.go
+╰── 📄 notes
+       more
+📝 README.md
+*  custom
+`, tree.PrintStyle(BoxStyle, WithIconFunc(FileIcon)))
+
+	// the icon goes between the fold marker and the label
+	assert.Equal(t, `▾ 📁 src
+├──   🐹 main.go
+╰──   📄 notes
+         more
+  📝 README.md
+  *  custom
+`, tree.PrintStyle(BoxStyle, WithIconFunc(FileIcon), WithFoldMarkers()))
+}
+
+func TestWithIconFunc_Empty(t *testing.T) {
+	// branches without an icon are indented like the others
+	tree := NewTree()
+	tree.AddBranches("a", "b")
+	assert.Equal(t, `🅰  a
+   b
+`, tree.PrintStyle(BoxStyle, WithIconFunc(func(branch *Tree) string {
+		if branch.Label == "a" {
+			return "🅰"
+		}
+		return ""
+	})))
+}
+
+func TestWithIconFunc_Alignment(t *testing.T) {
+	tree := NewTree()
+	src := tree.AddBranch("src")
+	src.AddBranch("main.go")
+	src.AddBranch("notes\nmore")
+	tree.AddBranch("README.md")
+	tree.AddBranch("custom").Icon = "*"
+
+	assert.Equal(t, `                       📁 .
+         ╭──────────────┴──┬────────────╮
+       📁 src         📝 README.md  *  custom
+    ╭────┴─────╮
+🐹 main.go  📄 notes
+               more
+`, tree.PrintStyle(BoxStyle, WithIconFunc(FileIcon), WithLayout(TopDownLayout), WithVisibleRoot(), WithMaxWidth(0)))
+}
+
+func TestWithIconFunc_References(t *testing.T) {
+	tree := NewTree()
+	shared := NewTree()
+	shared.Label = "lib"
+	shared.AddBranch("a.go")
+	tree.AddTreeAsBranch(shared)
+	tree.AddTreeAsBranch(shared)
+	assert.Equal(t, `📁 lib
+╰── 🐹 a.go
+📁 lib (*) see above
+`, tree.PrintStyle(BoxStyle, WithIconFunc(FileIcon), WithBackReferences()))
+}
+
+func TestFileIcon(t *testing.T) {
+	dir := &Tree{Label: "empty", Payload: dirEntry(true)}
+	assert.Equal(t, DirectoryIcon, FileIcon(dir))
+	assert.Equal(t, DirectoryIcon, FileIcon(&Tree{Label: "lib", Branches: []*Tree{{Label: "a"}}}))
+	assert.Equal(t, "🐹", FileIcon(&Tree{Label: "src/main.GO"}))
+	assert.Equal(t, "🐳", FileIcon(&Tree{Label: "Dockerfile"}))
+	assert.Equal(t, DefaultFileIcon, FileIcon(&Tree{Label: "data.bin"}))
+	assert.Equal(t, DefaultFileIcon, FileIcon(&Tree{Label: "lib", Payload: dirEntry(false), Branches: []*Tree{{}}}))
+}
+
+func TestIcon_Compact(t *testing.T) {
+	tree := NewTree()
+	a := tree.AddBranch("a")
+	a.Icon = "@"
+	a.AddBranch("b").AddBranch("c")
+	tree.Compact("/")
+	assert.Equal(t, []string{"a", "b/c"}, []string{tree.Branches[0].Label, tree.Branches[0].Branches[0].Label})
+	assert.Equal(t, "@", tree.Clone().Branches[0].Icon)
+}
+
+// dirEntry is a Payload that tells whether it is a directory, like os.FileInfo
+type dirEntry bool
+
+func (entry dirEntry) IsDir() bool {
+	return bool(entry)
+}
+
+func ExampleFileIcon() {
+	tree := NewTree()
+	if err := addFiles(tree, "testdata"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Print(tree.PrintStyle(BoxStyle, WithIconFunc(FileIcon)))
+	// Output:
+	// 📁 Mom and Dad (parents)
+	// ├── 📁 Bonita (sister)
+	// │   ├── 📄 Oscar (nephew)
+	// │   ╰── 📄 Shirley (neice)
+	// ├── 📁 Ego (me)
+	// │   ├── 📄 Bart (son)
+	// │   ╰── 📁 Marisa (daughter)
+	// │       ╰── 📄 Grace (grandchild)
+	// ╰── 📁 Harold (brother)
+	//     ╰── 📄 Isaac (nephew)
+	// 📁 Reynold (uncle)
+	// ╰── 📄 Travis (cousin)
+}
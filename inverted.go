@@ -0,0 +1,104 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// verticalMirror maps glyphs to the glyphs that they become when flipped upside down
+var verticalMirror = map[rune]rune{
+	'╰': '╭', '╭': '╰',
+	'└': '┌', '┌': '└',
+	'┗': '┏', '┏': '┗',
+	'╯': '╮', '╮': '╯',
+	'┘': '┐', '┐': '┘',
+	'┛': '┓', '┓': '┛',
+	'┴': '┬', '┬': '┴',
+	'┻': '┳', '┳': '┻',
+	'\'': ',', ',': '\'',
+}
+
+// printInverted is the internal, recursive hook for printing the tree upside down. The
+// branches of each branch are printed above it, so the root ends up at the bottom of the
+// output and the connectors run upward from each parent to its first branch
+func (tree *Tree) printInverted(p *printer, depth int, padding string) {
+	for index := range tree.Branches {
+		branch := p.enter(tree.Branches[index])
+		scaffold := p.scaffoldFor(branch, depth)
+
+		// the branches of this branch come first
+		branch.printInverted(p, depth+1, padding+tree.invertedBranchPadding(depth, index, scaffold))
+		p.leave(branch)
+
+		// handle each line of a block of text separately
+		for lineIndex, line := range strings.Split(branch.Label, "\n") {
+			if lineIndex == 0 {
+				p.addLine(scaffold, padding, tree.invertedLabelPadding(depth, index, scaffold), line)
+			} else {
+				// subsequent lines of a block of text always lie between the label and the parent
+				p.addLine(scaffold, padding, tree.invertedFlowPadding(depth, scaffold), line)
+			}
+		}
+	}
+}
+
+// invertedLabelPadding returns the scaffold in front of the first line of a label when the tree
+// is printed upside down. The first branch is the one farthest from the parent, so it gets the
+// corner glyph
+func (tree *Tree) invertedLabelPadding(depth int, index int, scaffold scaffolding) string {
+	if depth == 0 {
+		return ""
+	}
+
+	if scaffold.isList {
+		// scaffold is a bulleted or numbered list
+		offset := (depth - 1) % (len(scaffold.markup) - 1)
+		return tree.replaceNumberListMarkup(scaffold.markup[levelList+offset], index+1)
+	}
+
+	// scaffold is structural
+	if index == 0 {
+		return flipVertical(scaffold.markup[lastBranchScaffold])
+	}
+	return flipVertical(scaffold.markup[midBranchScaffold])
+}
+
+// invertedFlowPadding returns the scaffold in front of the lines that follow the first line of
+// a label when the tree is printed upside down. These lines are always between the label and
+// the parent, so they are bypassed by the connector
+func (tree *Tree) invertedFlowPadding(depth int, scaffold scaffolding) string {
+	if depth == 0 {
+		return ""
+	}
+
+	if scaffold.isList {
+		return scaffold.markup[indentList]
+	}
+	return flipVertical(scaffold.markup[bypassBranchScaffold])
+}
+
+// invertedBranchPadding returns the scaffold in front of the branches of a branch when the tree
+// is printed upside down. These are printed above the branch, so the connector only bypasses
+// them if there is an earlier sibling above
+func (tree *Tree) invertedBranchPadding(depth int, index int, scaffold scaffolding) string {
+	if depth == 0 {
+		return ""
+	}
+
+	if scaffold.isList {
+		return scaffold.markup[indentList]
+	}
+	if index == 0 {
+		return flipVertical(scaffold.markup[noBranchScaffold])
+	}
+	return flipVertical(scaffold.markup[bypassBranchScaffold])
+}
+
+// flipVertical returns the markup as it looks when flipped upside down
+func flipVertical(markup string) string {
+	return strings.Map(func(r rune) rune {
+		if flipped, ok := verticalMirror[r]; ok {
+			return flipped
+		}
+		return r
+	}, markup)
+}
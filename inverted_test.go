@@ -0,0 +1,95 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInverted(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("1")
+	branchA := root.AddBranch("a")
+	branchA.AddBranches("i", "ii")
+	root.AddBranch("b\nB").AddBranch("iii")
+
+	cases := []struct {
+		style    TreeStyle
+		expected string
+	}{
+		{style: ASCIIStyle, expected: `    ,-- i
+    |-- ii
+,-- a
+|   ,-- iii
+|-- b
+|   B
+1
+`},
+		{style: BoxStyle, expected: `    ╭── i
+    ├── ii
+╭── a
+│   ╭── iii
+├── b
+│   B
+1
+`},
+		{style: BoxBoldNarrowStyle, expected: `  ┏ i
+  ┣ ii
+┏ a
+┃ ┏ iii
+┣ b
+┃ B
+1
+`},
+		{style: NumberStyle, expected: `     1. i
+     2. ii
+ 1. a
+     1. iii
+ 2. b
+    B
+1
+`},
+	}
+
+	for index, tc := range cases {
+		t.Logf("Style %d:\n%s", tc.style, tree.PrintStyle(tc.style, WithInverted()))
+		assert.Equal(t, tc.expected, tree.PrintStyle(tc.style, WithInverted()), "Test case %d failed", index)
+	}
+}
+
+func TestInverted_MultipleRoots(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("1").AddBranches("a", "b")
+	tree.AddBranch("2").AddBranch("c")
+
+	result := tree.PrintStyle(ASCIIStyle, WithInverted())
+	assert.Equal(t, `,-- a
+|-- b
+1
+,-- c
+2
+`, result)
+}
+
+func TestFlipVertical(t *testing.T) {
+	assert.Equal(t, "╭── ", flipVertical("╰── "))
+	assert.Equal(t, "┏━━ ", flipVertical("┗━━ "))
+	assert.Equal(t, ",-- ", flipVertical("'-- "))
+	assert.Equal(t, "├── ", flipVertical("├── "))
+	assert.Equal(t, "*- ", flipVertical("*- "))
+}
+
+func ExampleWithInverted() {
+	tree := NewTree()
+	root := tree.AddBranch("/home (12G)")
+	root.AddBranch("alice (8G)").AddBranches("photos (6G)", "music (2G)")
+	root.AddBranch("bob (4G)")
+	fmt.Print(tree.PrintStyle(BoxStyle, WithInverted()))
+	// Output:
+	//     ╭── photos (6G)
+	//     ├── music (2G)
+	// ╭── alice (8G)
+	// ├── bob (4G)
+	// /home (12G)
+}
@@ -0,0 +1,245 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrMergeConflict is returned by MergeFrom when two branches that are merged carry different
+// payloads and the conflict is resolved with FailOnConflict
+var ErrMergeConflict = errors.New("merge conflict")
+
+// ConflictFunc resolves a conflict between the payloads of two branches that are merged
+// together. It is only called when both branches carry a payload and the payloads are
+// different. It returns the payload of the merged branch, or an error if the conflict can not
+// be resolved
+type ConflictFunc func(existing, incoming *Tree) (interface{}, error)
+
+// KeepExisting is a ConflictFunc that keeps the payload of the branch that was merged into
+func KeepExisting(existing, incoming *Tree) (interface{}, error) {
+	return existing.Payload, nil
+}
+
+// KeepIncoming is a ConflictFunc that replaces the payload with the one of the branch that was
+// merged in
+func KeepIncoming(existing, incoming *Tree) (interface{}, error) {
+	return incoming.Payload, nil
+}
+
+// FailOnConflict is a ConflictFunc that refuses to merge branches with different payloads
+func FailOnConflict(existing, incoming *Tree) (interface{}, error) {
+	return nil, ErrMergeConflict
+}
+
+// MetadataConflictFunc resolves a conflict between the metadata of two branches that are merged
+// together: their URL or their Icon, as named by the field. It is only called when both branches
+// set the field and the values are different. It returns the value of the merged branch, or an
+// error if the conflict can not be resolved
+type MetadataConflictFunc func(field, existing, incoming string) (string, error)
+
+// KeepExistingMetadata is a MetadataConflictFunc that keeps the value of the branch that was
+// merged into
+func KeepExistingMetadata(field, existing, incoming string) (string, error) {
+	return existing, nil
+}
+
+// KeepIncomingMetadata is a MetadataConflictFunc that replaces the value with the one of the
+// branch that was merged in
+func KeepIncomingMetadata(field, existing, incoming string) (string, error) {
+	return incoming, nil
+}
+
+// FailOnMetadataConflict is a MetadataConflictFunc that refuses to merge branches with different
+// metadata
+func FailOnMetadataConflict(field, existing, incoming string) (string, error) {
+	return "", ErrMergeConflict
+}
+
+// MergeOption customizes how MergeFrom combines trees
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	key        KeyFunc
+	conflict   ConflictFunc
+	metadata   MetadataConflictFunc
+	duplicates bool // keep siblings with equal keys apart
+}
+
+// WithMergeKey identifies the branches that are merged together with a custom key instead of
+// their label
+func WithMergeKey(key KeyFunc) MergeOption {
+	return func(config *mergeConfig) {
+		if key != nil {
+			config.key = key
+		}
+	}
+}
+
+// WithConflict sets how conflicting payloads are resolved. The default is KeepExisting
+func WithConflict(conflict ConflictFunc) MergeOption {
+	return func(config *mergeConfig) {
+		if conflict != nil {
+			config.conflict = conflict
+		}
+	}
+}
+
+// WithMetadataConflict sets how conflicting URLs and Icons are resolved. The default is
+// KeepExistingMetadata
+func WithMetadataConflict(conflict MetadataConflictFunc) MergeOption {
+	return func(config *mergeConfig) {
+		if conflict != nil {
+			config.metadata = conflict
+		}
+	}
+}
+
+// WithDuplicates keeps siblings with equal labels apart. Each branch that is merged in is only
+// unified with a branch that no other branch with the same label has been unified with, so a
+// tree with two "log" branches merged into a tree with one "log" branch results in two "log"
+// branches. By default, all siblings with equal labels are unified into one branch
+func WithDuplicates() MergeOption {
+	return func(config *mergeConfig) {
+		config.duplicates = true
+	}
+}
+
+// Merge combines trees into a new tree. Branches with equal labels are unified, recursively, so
+// each path of labels appears once in the result. The trees are not modified and the result
+// does not share any branches with them. Conflicting payloads and metadata are resolved by
+// keeping the first.
+//
+// As with AddTreeAsBranch, a tree without a label is a root whose branches are merged, and a tree
+// with a label is merged as a branch. So per-host trees labeled "web1" and "web2" end up side by
+// side, while two trees labeled "/" are unified
+func Merge(trees ...*Tree) *Tree {
+	merged := NewTree()
+	for _, tree := range trees {
+		if tree.Label != "" {
+			tree = &Tree{Branches: []*Tree{tree}}
+		}
+		// with the default options merging never fails
+		_ = merged.MergeFrom(tree)
+	}
+	return merged
+}
+
+// MergeFrom merges another tree into this tree. The other tree is treated as a counterpart of
+// this tree (their labels are not compared) and each of its branches is unified with the branch
+// of this tree that has the same label, recursively. Branches that have no counterpart are
+// copied into this tree, so it never shares branches with the other tree.
+//
+// When both branches carry different payloads, the conflict is resolved with the ConflictFunc
+// set by WithConflict. Metadata that only the other branch sets is copied, a branch is Collapsed
+// if either branch is, and different URLs or Icons are resolved with the MetadataConflictFunc set
+// by WithMetadataConflict. If resolving fails, the error is returned and this tree is left
+// partially merged.
+// Branches of the other tree that loop back to one of their ancestors are not merged again
+func (tree *Tree) MergeFrom(other *Tree, options ...MergeOption) error {
+	config := &mergeConfig{
+		key:      LabelKey,
+		conflict: KeepExisting,
+		metadata: KeepExistingMetadata,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return tree.merge(other, config, nil, ancestry{other: true})
+}
+
+// merge is the internal, recursive hook for merging trees. The path is the labels of the
+// branches above this tree, for error messages, and the ancestry is that of the other tree
+func (tree *Tree) merge(other *Tree, config *mergeConfig, path []string, ancestors ancestry) error {
+	if err := tree.mergePayload(other, config); err != nil {
+		return fmt.Errorf("%w at %q", err, "/"+strings.Join(path, "/"))
+	}
+	if err := tree.mergeMetadata(other, config); err != nil {
+		return fmt.Errorf("%w at %q", err, "/"+strings.Join(path, "/"))
+	}
+
+	unified := make(map[*Tree]bool)
+	for _, incoming := range other.Branches {
+		if ancestors[incoming] {
+			continue
+		}
+		branch := tree.mergeTarget(incoming, config, unified)
+		if branch == nil {
+			// the payload, metadata and branches are merged into the new branch below
+			branch = tree.AddBranch(incoming.Label)
+		}
+		unified[branch] = true
+		ancestors[incoming] = true
+		err := branch.merge(incoming, config, appendPath(path, incoming.Label), ancestors)
+		delete(ancestors, incoming)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeTarget returns the branch of this tree that an incoming branch should be unified with, or
+// nil if there is none
+func (tree *Tree) mergeTarget(incoming *Tree, config *mergeConfig, unified map[*Tree]bool) *Tree {
+	key := config.key(incoming)
+	for _, branch := range tree.Branches {
+		if config.duplicates && unified[branch] {
+			continue
+		}
+		if config.key(branch) == key {
+			return branch
+		}
+	}
+	return nil
+}
+
+// mergePayload combines the payload of the other tree with the payload of this tree
+func (tree *Tree) mergePayload(other *Tree, config *mergeConfig) error {
+	switch {
+	case other.Payload == nil:
+		return nil
+	case tree.Payload == nil:
+		tree.Payload = other.Payload
+		return nil
+	case reflect.DeepEqual(tree.Payload, other.Payload):
+		return nil
+	}
+
+	payload, err := config.conflict(tree, other)
+	if err != nil {
+		return err
+	}
+	tree.Payload = payload
+	return nil
+}
+
+// mergeMetadata combines the URL, Icon and Collapsed fields of the other tree with those of this
+// tree
+func (tree *Tree) mergeMetadata(other *Tree, config *mergeConfig) error {
+	tree.Collapsed = tree.Collapsed || other.Collapsed
+	for _, field := range []struct {
+		name  string
+		value *string
+		other string
+	}{
+		{"URL", &tree.URL, other.URL},
+		{"Icon", &tree.Icon, other.Icon},
+	} {
+		switch {
+		case field.other == "" || field.other == *field.value:
+			continue
+		case *field.value == "":
+			*field.value = field.other
+			continue
+		}
+
+		value, err := config.metadata(field.name, *field.value, field.other)
+		if err != nil {
+			return fmt.Errorf("%w in %s", err, field.name)
+		}
+		*field.value = value
+	}
+	return nil
+}
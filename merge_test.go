@@ -0,0 +1,204 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	host1 := NewTree()
+	etc := host1.AddBranch("etc")
+	etc.AddBranches("hosts", "passwd")
+	host1.AddBranch("var").AddBranch("log")
+
+	host2 := NewTree()
+	etc = host2.AddBranch("etc")
+	etc.AddBranches("passwd", "fstab")
+	host2.AddBranch("opt")
+
+	merged := Merge(host1, host2)
+
+	assert.Equal(t, `etc
+|-- hosts
+|-- passwd
+'-- fstab
+var
+'-- log
+opt
+`, merged.PrintStyle(ASCIIStyle))
+
+	// the merged tree is a copy
+	merged.Branches[0].AddBranch("group")
+	assert.Len(t, host1.Branches[0].Branches, 2)
+	assert.Len(t, host2.Branches[0].Branches, 2)
+}
+
+func TestMerge_Labeled(t *testing.T) {
+	// like AddTreeAsBranch, trees with a label are merged as branches
+	web1 := NewTree()
+	web1.Label = "web1"
+	web1.AddBranch("etc").AddBranch("hosts")
+	web2 := NewTree()
+	web2.Label = "web2"
+	web2.AddBranch("etc").AddBranch("fstab")
+	again := NewTree()
+	again.Label = "web1"
+	again.AddBranch("etc").AddBranch("passwd")
+
+	merged := Merge(web1, web2, again)
+	assert.Equal(t, "", merged.Label)
+	assert.Equal(t, `web1
+'-- etc
+    |-- hosts
+    '-- passwd
+web2
+'-- etc
+    '-- fstab
+`, merged.PrintStyle(ASCIIStyle))
+	assert.Nil(t, web1.Parent(), "the trees are not modified")
+}
+
+func TestMergeFrom_Payload(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("a").Payload = 1
+	tree.AddBranch("b")
+
+	other := NewTree()
+	other.AddBranch("a").Payload = 2
+	other.AddBranch("b").Payload = "bravo"
+
+	// by default the existing payload is kept, and missing payloads are filled in
+	merged := Merge(tree, other)
+	assert.Equal(t, 1, merged.Branches[0].Payload)
+	assert.Equal(t, "bravo", merged.Branches[1].Payload)
+
+	// the incoming payload can win
+	merged = Merge(tree)
+	assert.NoError(t, merged.MergeFrom(other, WithConflict(KeepIncoming)))
+	assert.Equal(t, 2, merged.Branches[0].Payload)
+
+	// or a custom function can resolve the conflict
+	merged = Merge(tree)
+	err := merged.MergeFrom(other, WithConflict(func(existing, incoming *Tree) (interface{}, error) {
+		return existing.Payload.(int) + incoming.Payload.(int), nil
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, merged.Branches[0].Payload)
+
+	// equal payloads are not a conflict
+	merged = Merge(tree)
+	assert.NoError(t, merged.MergeFrom(tree, WithConflict(FailOnConflict)))
+}
+
+func TestMerge_Metadata(t *testing.T) {
+	tree := NewTree()
+	docs := tree.AddBranch("docs")
+	docs.URL = "https://example.com/docs"
+	docs.Icon = "📁"
+	docs.Collapsed = true
+	docs.AddBranch("index.md")
+
+	// branches that are copied into the merged tree keep their metadata
+	merged := Merge(tree)
+	assert.Equal(t, "https://example.com/docs", merged.Branches[0].URL)
+	assert.Equal(t, "📁", merged.Branches[0].Icon)
+	assert.True(t, merged.Branches[0].Collapsed)
+	assert.Equal(t, []string{"docs", "index.md"}, merged.Branches[0].Branches[0].Path())
+}
+
+func TestMergeFrom_Metadata(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("src").AddBranch("main.go")
+	other := NewTree()
+	src := other.AddBranch("src")
+	src.URL = "https://example.com/src"
+	src.Icon = "📁"
+	src.Collapsed = true
+
+	// metadata that only the incoming branch has is filled in
+	merged := Merge(tree, other)
+	assert.Equal(t, "https://example.com/src", merged.Branches[0].URL)
+	assert.Equal(t, "📁", merged.Branches[0].Icon)
+	assert.True(t, merged.Branches[0].Collapsed)
+
+	// different values are a conflict, which keeps the existing value by default
+	conflicting := NewTree()
+	src = conflicting.AddBranch("src")
+	src.URL = "https://example.org/src"
+	src.Icon = "🗂"
+	merged = Merge(other, conflicting)
+	assert.Equal(t, "https://example.com/src", merged.Branches[0].URL)
+	assert.Equal(t, "📁", merged.Branches[0].Icon)
+
+	merged = Merge(other)
+	assert.NoError(t, merged.MergeFrom(conflicting, WithMetadataConflict(KeepIncomingMetadata)))
+	assert.Equal(t, "https://example.org/src", merged.Branches[0].URL)
+	assert.Equal(t, "🗂", merged.Branches[0].Icon)
+	assert.True(t, merged.Branches[0].Collapsed, "a branch stays collapsed if either one is")
+
+	merged = Merge(other)
+	err := merged.MergeFrom(conflicting, WithMetadataConflict(FailOnMetadataConflict))
+	assert.True(t, errors.Is(err, ErrMergeConflict))
+	assert.EqualError(t, err, `merge conflict in URL at "/src"`)
+
+	// the payload conflict function is not asked about metadata
+	merged = Merge(other)
+	assert.NoError(t, merged.MergeFrom(conflicting, WithConflict(FailOnConflict)))
+}
+
+func TestMergeFrom_Conflict(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("a").AddBranch("b").Payload = "one"
+	other := NewTree()
+	other.AddBranch("a").AddBranch("b").Payload = "two"
+
+	err := tree.MergeFrom(other, WithConflict(FailOnConflict))
+	assert.True(t, errors.Is(err, ErrMergeConflict))
+	assert.EqualError(t, err, `merge conflict at "/a/b"`)
+}
+
+func TestMergeFrom_Duplicates(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("log")
+
+	other := NewTree()
+	other.AddBranch("log").AddBranch("one")
+	other.AddBranch("log").AddBranch("two")
+
+	unified := Merge(tree)
+	assert.NoError(t, unified.MergeFrom(other))
+	assert.Equal(t, "log\n|-- one\n'-- two\n", unified.PrintStyle(ASCIIStyle))
+
+	duplicates := Merge(tree)
+	assert.NoError(t, duplicates.MergeFrom(other, WithDuplicates()))
+	assert.Equal(t, "log\n'-- one\nlog\n'-- two\n", duplicates.PrintStyle(ASCIIStyle))
+}
+
+func TestMergeFrom_Key(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("README.md")
+	other := NewTree()
+	other.AddBranch("readme.MD")
+
+	assert.NoError(t, tree.MergeFrom(other, WithMergeKey(func(branch *Tree) string {
+		return fmt.Sprint(len(branch.Label))
+	})))
+	assert.Len(t, tree.Branches, 1)
+}
+
+func ExampleMerge() {
+	web1 := NewTree()
+	web1.AddBranch("nginx").AddBranches("sites-enabled", "nginx.conf")
+	web2 := NewTree()
+	web2.AddBranch("nginx").AddBranches("nginx.conf", "mime.types")
+
+	fmt.Print(Merge(web1, web2).Print())
+	// Output:
+	// nginx
+	// ├── sites-enabled
+	// ├── nginx.conf
+	// ╰── mime.types
+}
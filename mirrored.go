@@ -0,0 +1,71 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// horizontalMirror maps glyphs to the glyphs that they become when flipped left to right
+var horizontalMirror = map[rune]rune{
+	'├': '┤', '┤': '├',
+	'┣': '┫', '┫': '┣',
+	'╰': '╯', '╯': '╰',
+	'╭': '╮', '╮': '╭',
+	'└': '┘', '┘': '└',
+	'┌': '┐', '┐': '┌',
+	'┗': '┛', '┛': '┗',
+	'┏': '┓', '┓': '┏',
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'/': '\\', '\\': '/',
+}
+
+// mirroredLines assembles the lines of output with the scaffold on the right of each label and
+// the labels right aligned to the width of the widest line
+func (p *printer) mirroredLines() []string {
+	width := 0
+	for _, line := range p.lines {
+		if lineWidth := textWidth(line.padding + line.markup + line.text); lineWidth > width {
+			width = lineWidth
+		}
+	}
+
+	mirrored := make([]string, 0, len(p.lines))
+	for _, line := range p.lines {
+		markup := flipHorizontal(line.markup)
+		if line.scaffold.isList {
+			// bullets and numbers must stay readable, so only the spacing around them is mirrored
+			markup = mirrorSpacing(line.markup)
+		}
+		scaffold := markup + flipHorizontal(line.padding)
+		text := strings.Repeat(" ", width-textWidth(line.text+scaffold)) + line.text
+		if scaffold = strings.TrimRight(scaffold, " "); scaffold == "" {
+			text = strings.TrimRight(text, " ")
+		}
+		mirrored = append(mirrored, text+line.colorize(scaffold))
+	}
+	return mirrored
+}
+
+// flipHorizontal returns the markup as it looks when flipped left to right
+func flipHorizontal(markup string) string {
+	runes := []rune(markup)
+	flipped := make([]rune, len(runes))
+	for index, r := range runes {
+		if mirror, ok := horizontalMirror[r]; ok {
+			r = mirror
+		}
+		flipped[len(runes)-1-index] = r
+	}
+	return string(flipped)
+}
+
+// mirrorSpacing swaps the spaces on the left of the markup with the spaces on the right
+func mirrorSpacing(markup string) string {
+	trimmed := strings.TrimLeft(markup, " ")
+	left := len(markup) - len(trimmed)
+	core := strings.TrimRight(trimmed, " ")
+	right := len(trimmed) - len(core)
+	return strings.Repeat(" ", right) + core + strings.Repeat(" ", left)
+}
@@ -0,0 +1,91 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrored(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("1")
+	branchA := root.AddBranch("a")
+	branchA.AddBranch("i")
+	root.AddBranch("b\nB")
+
+	cases := []struct {
+		style    TreeStyle
+		expected string
+	}{
+		{style: ASCIIStyle, expected: `        1
+    a --|
+i --'   |
+    b --'
+    B
+`},
+		{style: BoxStyle, expected: `        1
+    a ──┤
+i ──╯   │
+    b ──╯
+    B
+`},
+		{style: BoxBoldNarrowStyle, expected: `    1
+  a ┫
+i ┛ ┃
+  b ┛
+  B
+`},
+		{style: BulletStyle, expected: `    1
+  a ●
+i ○
+  b ●
+  B
+`},
+	}
+
+	for index, tc := range cases {
+		t.Logf("Style %d:\n%s", tc.style, tree.PrintStyle(tc.style, WithMirrored()))
+		assert.Equal(t, tc.expected, tree.PrintStyle(tc.style, WithMirrored()), "Test case %d failed", index)
+	}
+}
+
+func TestMirrored_Inverted(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("1").AddBranches("a", "b")
+
+	result := tree.PrintStyle(BoxStyle, WithMirrored(), WithInverted())
+	assert.Equal(t, `a ──╮
+b ──┤
+    1
+`, result)
+}
+
+func TestFlipHorizontal(t *testing.T) {
+	assert.Equal(t, " ──┤", flipHorizontal("├── "))
+	assert.Equal(t, " ──╯", flipHorizontal("╰── "))
+	assert.Equal(t, " --'", flipHorizontal("'-- "))
+	assert.Equal(t, "   │", flipHorizontal("│   "))
+	assert.Equal(t, " -<", flipHorizontal(">- "))
+}
+
+func TestMirrorSpacing(t *testing.T) {
+	assert.Equal(t, " ●", mirrorSpacing("● "))
+	assert.Equal(t, " 1. ", mirrorSpacing(" 1. "))
+	assert.Equal(t, " (1)   ", mirrorSpacing("   (1) "))
+	assert.Equal(t, "    ", mirrorSpacing("    "))
+}
+
+func ExampleWithMirrored() {
+	tree := NewTree()
+	root := tree.AddBranch("Total: 1,234")
+	root.AddBranch("North: 1,000")
+	root.AddBranch("South: 234").AddBranches("East: 200", "West: 34")
+	fmt.Print(tree.PrintStyle(BoxStyle, WithMirrored()))
+	// Output:
+	//      Total: 1,234
+	//  North: 1,000 ──┤
+	//    South: 234 ──╯
+	// East: 200 ──┤
+	//  West: 34 ──╯
+}
@@ -0,0 +1,261 @@
+package printtree
+
+import (
+	"os"
+	"strconv"
+)
+
+// Layout is the overall arrangement of a printed tree. The default IndentedLayout grows the tree
+// downward and to the right, other layouts arrange the branches around their parents
+type Layout int
+
+const (
+	// IndentedLayout prints one branch per line, indented under its parent
+	IndentedLayout Layout = iota
+	// TopDownLayout prints the tree like an org chart, with each parent centered above its
+	// branches
+	TopDownLayout
+	// LeftToRightLayout prints the tree with the root on the left and the branches fanning out
+	// to the right, with each parent vertically centered on its branches
+	LeftToRightLayout
+)
+
+// StyleFunc chooses the style of a single branch. The level is 1 for the branches of the top
+// level labels, 2 for the branches below those, and so on
+type StyleFunc func(branch *Tree, level int) TreeStyle
+
+// PrintOption customizes how PrintStyle prints a tree. Options are created with the `With...`
+// functions in this package
+type PrintOption func(*printConfig)
+
+// printConfig is the collected set of print options for a single call to PrintStyle
+type printConfig struct {
+	layout   Layout
+	maxWidth int  // maximum width of the output. 0 for unlimited, -1 to ask the terminal
+	inverted bool // print the root at the bottom
+	mirrored bool // print the scaffold on the right
+	tabWidth int  // distance between tab stops in labels
+
+	references   bool    // print shared branches in full only once
+	referenceKey KeyFunc // identifies shared branches. nil to identify them by identity
+
+	compact        bool // merge chains of single branches before printing
+	compactSep     string
+	compactOptions []CompactOption
+
+	styleFunc StyleFunc // chooses the style of each branch. nil to use the same style for all
+
+	visibleRoot bool // print the tree itself as the root of its branches
+
+	foldMarkers bool // print markers in front of branches that can be collapsed or expanded
+	hiddenCount bool // print the number of branches hidden by collapsed branches
+
+	hyperlinks Hyperlinks // print the URLs of branches as hyperlinks
+
+	iconFunc IconFunc // chooses the icons of branches without an Icon. nil for no icons
+}
+
+// newPrintConfig returns the configuration that results from applying the options, in order,
+// to the default configuration
+func newPrintConfig(options []PrintOption) *printConfig {
+	config := &printConfig{
+		layout:   IndentedLayout,
+		maxWidth: -1,
+		tabWidth: defaultTabWidth,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// WithLayout selects the layout of the printed tree. Layouts that are wider than the available
+// width (see WithMaxWidth) fall back to IndentedLayout
+func WithLayout(layout Layout) PrintOption {
+	return func(config *printConfig) {
+		config.layout = layout
+	}
+}
+
+// WithMaxWidth sets the width that the printed tree should fit into. A width of 0 or less means
+// there is no limit. If this option is not given, the output fits the width of the terminal
+// that is the standard output, or the COLUMNS environment variable when the standard output is
+// not a terminal
+func WithMaxWidth(width int) PrintOption {
+	return func(config *printConfig) {
+		if width < 0 {
+			width = 0
+		}
+		config.maxWidth = width
+	}
+}
+
+// WithInverted prints the tree upside down, with the root at the bottom and the branches of
+// each parent printed above it, which is the layout used by disk usage tools such as dust. The
+// most important line of the output then ends up next to the prompt. Only IndentedLayout can be
+// inverted
+func WithInverted() PrintOption {
+	return func(config *printConfig) {
+		config.inverted = true
+	}
+}
+
+// WithMirrored prints the tree right to left, with the scaffold on the right side of each label
+// and the labels right aligned. This suits labels in right-to-left languages and right aligned
+// dashboards. The glyphs of structural styles are mirrored, so "├── " becomes " ──┤". Only
+// IndentedLayout can be mirrored
+func WithMirrored() PrintOption {
+	return func(config *printConfig) {
+		config.mirrored = true
+	}
+}
+
+// WithTabWidth sets the distance between tab stops when tabs in labels are expanded to spaces.
+// The default is 8
+func WithTabWidth(width int) PrintOption {
+	return func(config *printConfig) {
+		if width > 0 {
+			config.tabWidth = width
+		}
+	}
+}
+
+// WithBackReferences prints a branch that is shared by several parents (see AddTreeAsBranch) in
+// full only the first time it is printed. Later occurrences are printed without their branches,
+// followed by a "(*) see above" marker. This keeps dependency graphs, where the same
+// dependencies are reached again and again, short
+func WithBackReferences() PrintOption {
+	return func(config *printConfig) {
+		config.references = true
+	}
+}
+
+// WithBackReferenceKey is like WithBackReferences, but branches are considered to be the same
+// when their keys are equal, even if they are different branches. For example, to treat all
+// branches with the same label as the same branch
+//   tree.PrintStyle(BoxStyle, WithBackReferenceKey(LabelKey))
+func WithBackReferenceKey(key KeyFunc) PrintOption {
+	return func(config *printConfig) {
+		config.references = true
+		config.referenceKey = key
+	}
+}
+
+// WithCompact prints each chain of branches that have a single branch as one branch, with the
+// labels joined by the separator. The tree itself is not changed. See Compact
+func WithCompact(separator string, options ...CompactOption) PrintOption {
+	return func(config *printConfig) {
+		config.compact = true
+		config.compactSep = separator
+		config.compactOptions = options
+	}
+}
+
+// WithDepthStyles prints each level of the tree in its own style. The first style is used for
+// the branches of the top level labels, the second for the branches below those, and so on. The
+// last style is used for all deeper levels. For example, a report with numbered sections and box
+// drawing below them
+//   tree.PrintStyle(BoxStyle, WithDepthStyles(NumberStyle, BoxStyle))
+// Only IndentedLayout supports mixed styles
+func WithDepthStyles(styles ...TreeStyle) PrintOption {
+	return func(config *printConfig) {
+		if len(styles) == 0 {
+			config.styleFunc = nil
+			return
+		}
+		config.styleFunc = func(branch *Tree, level int) TreeStyle {
+			if level > len(styles) {
+				level = len(styles)
+			}
+			return styles[level-1]
+		}
+	}
+}
+
+// WithStyleFunc chooses the style of every branch with a function, for example to print one
+// subtree differently from the rest. The style passed to PrintStyle is used for branches that
+// the function returns an unknown style for, such as -1. Only IndentedLayout supports mixed
+// styles
+func WithStyleFunc(styleFunc StyleFunc) PrintOption {
+	return func(config *printConfig) {
+		config.styleFunc = styleFunc
+	}
+}
+
+// WithVisibleRoot prints the tree itself as the first line, with the top level branches hanging
+// off it like all other branches. A tree without a label, such as the one returned by NewTree(),
+// is printed as "." the way the tree command does
+//   .
+//   ├── Monochrome
+//   ╰── Color
+// Without this option, the tree itself is not printed and its branches are printed as separate
+// roots without any scaffold
+func WithVisibleRoot() PrintOption {
+	return func(config *printConfig) {
+		config.visibleRoot = true
+	}
+}
+
+// WithFoldMarkers prints a marker in front of every branch that has branches of its own: "▸" if
+// the branch is Collapsed and "▾" if it is expanded ("+" and "-" in ASCII styles). Branches
+// without branches are indented to line up with the others
+//   ├── ▾ src
+//   │   ├──   main.go
+//   │   ╰── ▸ util
+//   ╰──   README.md
+// Collapsed branches are printed without their branches whether this option is given or not
+func WithFoldMarkers() PrintOption {
+	return func(config *printConfig) {
+		config.foldMarkers = true
+	}
+}
+
+// WithHiddenCount prints the number of branches that are hidden below each collapsed branch,
+// like "util (12 hidden)"
+func WithHiddenCount() PrintOption {
+	return func(config *printConfig) {
+		config.hiddenCount = true
+	}
+}
+
+// WithHyperlinks sets when the labels of branches with a URL are printed as hyperlinks, with
+// OSC 8 escape sequences. By default (HyperlinksOff), labels are printed as plain text, so the
+// output does not depend on the environment
+func WithHyperlinks(mode Hyperlinks) PrintOption {
+	return func(config *printConfig) {
+		config.hyperlinks = mode
+	}
+}
+
+// WithIconFunc prints an icon in front of the labels of branches that do not have an Icon of
+// their own, as chosen by the function. For example, FileIcon chooses icons by file extension
+//   ├── 📁 src
+//   │   ╰── 🐹 main.go
+//   ╰── 📝 README.md
+// Branches without an icon are indented to line up with the others
+func WithIconFunc(icon IconFunc) PrintOption {
+	return func(config *printConfig) {
+		config.iconFunc = icon
+	}
+}
+
+// width returns the maximum width the output should fit into, or 0 if there is no limit
+func (config *printConfig) width() int {
+	if config.maxWidth >= 0 {
+		return config.maxWidth
+	}
+	return terminalWidth()
+}
+
+// terminalWidth returns the width of the terminal that is the standard output, or as advertised
+// by the COLUMNS environment variable, or 0 if it is unknown
+func terminalWidth() int {
+	if columns := stdoutColumns(); columns > 0 {
+		return columns
+	}
+	columns, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || columns < 0 {
+		return 0
+	}
+	return columns
+}
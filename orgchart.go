@@ -0,0 +1,193 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// chartGap is the number of spaces between sibling subtrees in a chart
+const chartGap = 2
+
+// chartBlock is a rendered subtree of a chart layout. Every line is padded to the full width of
+// the block so that blocks can be placed side by side
+type chartBlock struct {
+	lines  []string
+	width  int
+	anchor int // column (top-down) or line (left-to-right) that the parent connects to
+}
+
+// printChart prints the tree with one of the chart layouts. Each top level branch is printed as
+// a separate chart. Returns false if the charts do not fit into the given width, in which case
+// nothing is printed
+func (tree *Tree) printChart(buf *strings.Builder, scaffold scaffolding, config *printConfig) bool {
+	maxWidth := config.width()
+	roots := tree.Branches
+	if config.visibleRoot {
+		// chart the whole tree as a single root
+		roots = []*Tree{{Label: tree.rootLabel(), Payload: tree.Payload, Collapsed: tree.Collapsed, URL: tree.URL, Icon: tree.Icon, Branches: tree.Branches}}
+	}
+
+	blocks := make([]chartBlock, 0, len(roots))
+	v := newVisitor(tree, scaffold, config)
+	for _, branch := range roots {
+		branch = v.enter(branch)
+		var block chartBlock
+		switch config.layout {
+		case LeftToRightLayout:
+			block = branch.horizontalBlock(scaffold.lines, config.tabWidth, v)
+		default:
+			block = branch.chartBlock(scaffold.lines, config.tabWidth, v)
+		}
+		v.leave(branch)
+		if maxWidth > 0 && block.width > maxWidth {
+			return false
+		}
+		blocks = append(blocks, block)
+	}
+
+	for index, block := range blocks {
+		if index > 0 {
+			// separate multiple roots with a blank line
+			buf.WriteString("\n")
+		}
+		for _, line := range block.lines {
+			buf.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	return true
+}
+
+// chartBlock recursively renders this tree, and all its branches, as an org chart with each
+// parent centered above its branches
+func (tree *Tree) chartBlock(glyphs lineGlyphs, tabWidth int, v *visitor) chartBlock {
+	labelLines, labelWidth := centerLines(splitLabel(tree.Label, tabWidth))
+	if labelWidth == 0 {
+		// an empty label still needs a column for the connector to hang from
+		labelWidth = 1
+		for index := range labelLines {
+			labelLines[index] = " "
+		}
+	}
+	if len(tree.Branches) == 0 {
+		return chartBlock{
+			lines:  labelLines,
+			width:  labelWidth,
+			anchor: (labelWidth - 1) / 2,
+		}
+	}
+
+	// lay out the branches side by side, remembering where each one is anchored
+	children := make([]chartBlock, 0, len(tree.Branches))
+	anchors := make([]int, 0, len(tree.Branches))
+	childrenWidth := 0
+	for index, branch := range tree.Branches {
+		if index > 0 {
+			childrenWidth += chartGap
+		}
+		branch = v.enter(branch)
+		child := branch.chartBlock(glyphs, tabWidth, v)
+		v.leave(branch)
+		children = append(children, child)
+		anchors = append(anchors, childrenWidth+child.anchor)
+		childrenWidth += child.width
+	}
+
+	// center the parent over the first and last branch. if the label is too wide to be
+	// centered there, shift the branches to the right to make room
+	anchor := (anchors[0] + anchors[len(anchors)-1]) / 2
+	labelStart := anchor - (labelWidth-1)/2
+	shift := 0
+	if labelStart < 0 {
+		shift = -labelStart
+		labelStart = 0
+		anchor += shift
+	}
+	width := childrenWidth + shift
+	if labelStart+labelWidth > width {
+		width = labelStart + labelWidth
+	}
+
+	block := chartBlock{
+		lines:  make([]string, 0, len(labelLines)+1),
+		width:  width,
+		anchor: anchor,
+	}
+	for _, line := range labelLines {
+		block.lines = append(block.lines, padRight(strings.Repeat(" ", labelStart)+line, width))
+	}
+	block.lines = append(block.lines, chartConnector(glyphs, width, anchor, anchors, shift))
+
+	// join the lines of the branches, padding short branches with blank lines
+	height := 0
+	for _, child := range children {
+		if len(child.lines) > height {
+			height = len(child.lines)
+		}
+	}
+	for lineIndex := 0; lineIndex < height; lineIndex++ {
+		line := strings.Builder{}
+		line.WriteString(strings.Repeat(" ", shift))
+		for index, child := range children {
+			if index > 0 {
+				line.WriteString(strings.Repeat(" ", chartGap))
+			}
+			if lineIndex < len(child.lines) {
+				line.WriteString(child.lines[lineIndex])
+			} else {
+				line.WriteString(strings.Repeat(" ", child.width))
+			}
+		}
+		block.lines = append(block.lines, padRight(line.String(), width))
+	}
+
+	return block
+}
+
+// chartConnector returns the line that connects a parent (at the anchor column) to each of its
+// branches (at the branch anchor columns, which are offset by shift)
+func chartConnector(glyphs lineGlyphs, width int, anchor int, branchAnchors []int, shift int) string {
+	cells := make([]string, width)
+	for index := range cells {
+		cells[index] = " "
+	}
+
+	first := branchAnchors[0] + shift
+	last := branchAnchors[len(branchAnchors)-1] + shift
+	if first == last {
+		// a single branch hangs straight down from its parent
+		cells[first] = glyphs.vertical
+		return strings.Join(cells, "")
+	}
+
+	for column := first; column <= last; column++ {
+		cells[column] = glyphs.horizontal
+	}
+	for _, branchAnchor := range branchAnchors[1 : len(branchAnchors)-1] {
+		cells[branchAnchor+shift] = glyphs.teeDown
+	}
+	cells[first] = glyphs.topLeft
+	cells[last] = glyphs.topRight
+	if cells[anchor] == glyphs.teeDown {
+		cells[anchor] = glyphs.cross
+	} else {
+		cells[anchor] = glyphs.teeUp
+	}
+	return strings.Join(cells, "")
+}
+
+// centerLines centers each line within the width of the widest line. Returns the centered
+// lines and their width
+func centerLines(lines []string) ([]string, int) {
+	width := 0
+	for _, line := range lines {
+		if lineWidth := textWidth(line); lineWidth > width {
+			width = lineWidth
+		}
+	}
+
+	centered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		left := (width - textWidth(line)) / 2
+		centered = append(centered, padRight(strings.Repeat(" ", left)+line, width))
+	}
+	return centered, width
+}
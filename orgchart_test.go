@@ -0,0 +1,136 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopDownLayout(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("Monitors")
+	monoTree := root.AddBranch("Monochrome")
+	monoTree.AddBranch("Old School").AddBranches("black", "green")
+	root.AddBranch("Color").AddBranches("red", "green", "blue")
+
+	result := tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0))
+	t.Logf("OUT :\n%s", result)
+	assert.Equal(t, `          Monitors
+     ╭───────┴───────╮
+ Monochrome        Color
+     │         ╭─────┼─────╮
+ Old School   red  green  blue
+  ╭──┴───╮
+black  green
+`, result)
+
+	result = tree.PrintStyle(ASCIIStyle, WithLayout(TopDownLayout), WithMaxWidth(0))
+	assert.Equal(t, `          Monitors
+     +-------+-------+
+ Monochrome        Color
+     |         +-----+-----+
+ Old School   red  green  blue
+  +--+---+
+black  green
+`, result)
+}
+
+func TestTopDownLayout_WideLabel(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("A very long label\nsecond line")
+	root.AddBranches("a", "b")
+
+	result := tree.PrintStyle(BoxBoldStyle, WithLayout(TopDownLayout), WithMaxWidth(0))
+	assert.Equal(t, `A very long label
+   second line
+       ┏┻━┓
+       a  b
+`, result)
+}
+
+func TestTopDownLayout_MultipleRoots(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("1").AddBranches("a", "b")
+	tree.AddBranch("2")
+
+	result := tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0))
+	assert.Equal(t, ` 1
+╭┴─╮
+a  b
+
+2
+`, result)
+}
+
+func TestTopDownLayout_Fallback(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("1")
+	root.AddBranches("alpha", "bravo", "charlie")
+
+	// fits, so the chart is used
+	result := tree.PrintStyle(ASCIIStyle, WithLayout(TopDownLayout), WithMaxWidth(21))
+	assert.Equal(t, `         1
+  +------+-------+
+alpha  bravo  charlie
+`, result)
+
+	// too wide, so it falls back to the indented layout
+	result = tree.PrintStyle(ASCIIStyle, WithLayout(TopDownLayout), WithMaxWidth(20))
+	assert.Equal(t, `1
+|-- alpha
+|-- bravo
+'-- charlie
+`, result)
+
+	// without a maximum width, the width of the terminal is used. When the standard output is
+	// not a terminal (as it usually is not under go test) that is taken from COLUMNS
+	if stdoutColumns() == 0 {
+		withEnv("COLUMNS", "20", func() {
+			result = tree.PrintStyle(ASCIIStyle, WithLayout(TopDownLayout))
+		})
+		assert.Equal(t, `1
+|-- alpha
+|-- bravo
+'-- charlie
+`, result)
+	}
+}
+
+func TestTopDownLayout_CustomStyle(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("1").AddBranches("a", "b")
+
+	asciiStyle := AddStructuralStyle(">- ", "*- ", "}  ", "...")
+	boxStyle := AddStructuralStyle("├─ ", "└─ ", "│  ", "   ")
+
+	assert.Equal(t, " 1\n++-+\na  b\n", tree.PrintStyle(asciiStyle, WithLayout(TopDownLayout), WithMaxWidth(0)))
+	assert.Equal(t, " 1\n╭┴─╮\na  b\n", tree.PrintStyle(boxStyle, WithLayout(TopDownLayout), WithMaxWidth(0)))
+}
+
+func ExampleWithLayout() {
+	tree := NewTree()
+	root := tree.AddBranch("Chief Executive Officer")
+	root.AddBranch("CTO").AddBranches("Dev", "Ops")
+	root.AddBranch("CFO")
+	fmt.Print(tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(80)))
+	// Output:
+	// Chief Executive Officer
+	//        ╭───┴───╮
+	//       CTO     CFO
+	//      ╭─┴──╮
+	//     Dev  Ops
+}
+
+func TestTopDownLayout_EmptyLabels(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("root").AddBranches("a", "")
+	assert.Equal(t, `root
+╭┴─╮
+a
+`, tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0)))
+
+	tree = NewTree()
+	tree.AddBranch("root").AddBranch("").AddBranch("")
+	assert.Equal(t, "root\n │\n\n │\n\n", tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0)))
+}
@@ -0,0 +1,83 @@
+package printtree
+
+// Parent returns the tree that this branch was added to, or nil if this is a root. A branch that
+// was grafted into several trees with AddTreeAsBranch belongs to the tree it was grafted into
+// last. Branches that are added by appending to Branches directly have no parent
+func (tree *Tree) Parent() *Tree {
+	return tree.parent
+}
+
+// Root returns the top of the tree that this branch belongs to, which is usually the unlabeled
+// tree returned by NewTree()
+func (tree *Tree) Root() *Tree {
+	root := tree
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// Level returns the number of parents above this branch. A root is level 0, its branches are
+// level 1, and so on
+func (tree *Tree) Level() int {
+	level := 0
+	for ancestor := tree.parent; ancestor != nil; ancestor = ancestor.parent {
+		level++
+	}
+	return level
+}
+
+// Path returns the labels of the branches from the top of the tree down to, and including, this
+// branch. The label of an unlabeled root is not included, so for the tree
+//   root := NewTree()
+//   root.AddBranch("usr").AddBranch("local").AddBranch("bin")
+// the path of "bin" is ["usr", "local", "bin"]
+func (tree *Tree) Path() []string {
+	var path []string
+	for branch := tree; branch != nil; branch = branch.parent {
+		if branch.parent == nil && branch.Label == "" {
+			break
+		}
+		path = append(path, branch.Label)
+	}
+
+	// the path was collected from the bottom up
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// LowestCommonAncestor returns the deepest branch that both a and b descend from. A branch is
+// considered to descend from itself, so if a is an ancestor of b then a is returned. Returns nil
+// if the branches are not in the same tree
+func LowestCommonAncestor(a, b *Tree) *Tree {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	// bring both branches up to the same level, then walk up in step until they meet
+	levelA, levelB := a.Level(), b.Level()
+	for ; levelA > levelB; levelA-- {
+		a = a.parent
+	}
+	for ; levelB > levelA; levelB-- {
+		b = b.parent
+	}
+	for a != b {
+		a, b = a.parent, b.parent
+	}
+	return a
+}
+
+// adopt makes this tree the parent of the branch, unless the branch is this tree or one of its
+// ancestors. Following the parents of a branch therefore always ends at a root, even when
+// grafting has created a cycle
+func (tree *Tree) adopt(branch *Tree) {
+	for ancestor := tree; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == branch {
+			return
+		}
+	}
+	branch.parent = tree
+}
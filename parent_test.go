@@ -0,0 +1,112 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParent(t *testing.T) {
+	tree := NewTree()
+	usr := tree.AddBranch("usr")
+	local := usr.AddBranch("local")
+	bin := local.AddBranch("bin")
+
+	assert.Nil(t, tree.Parent())
+	assert.Same(t, tree, usr.Parent())
+	assert.Same(t, local, bin.Parent())
+
+	assert.Same(t, tree, bin.Root())
+	assert.Same(t, tree, tree.Root())
+
+	assert.Equal(t, 0, tree.Level())
+	assert.Equal(t, 1, usr.Level())
+	assert.Equal(t, 3, bin.Level())
+
+	assert.Equal(t, []string{"usr", "local", "bin"}, bin.Path())
+	assert.Equal(t, []string{"usr"}, usr.Path())
+	assert.Empty(t, tree.Path())
+
+	// a labeled root is part of the path
+	root := &Tree{Label: "/"}
+	assert.Equal(t, []string{"/", "etc"}, root.AddBranch("etc").Path())
+}
+
+func TestParent_AddTreeAsBranch(t *testing.T) {
+	tree := NewTree()
+	usr := tree.AddBranch("usr")
+
+	// grafting a root adopts all its branches
+	other := NewTree()
+	lib := other.AddBranch("lib")
+	share := other.AddBranch("share")
+	usr.AddTreeAsBranch(other)
+	assert.Same(t, usr, lib.Parent())
+	assert.Same(t, usr, share.Parent())
+	assert.Equal(t, []string{"usr", "lib"}, lib.Path())
+
+	// grafting a branch moves it to its latest parent
+	opt := tree.AddBranch("opt")
+	opt.AddTreeAsBranch(lib)
+	assert.Same(t, opt, lib.Parent())
+	assert.Equal(t, []string{"opt", "lib"}, lib.Path())
+
+	// grafting an ancestor does not make its parents loop
+	lib.AddTreeAsBranch(opt)
+	assert.Same(t, tree, opt.Parent())
+	assert.Same(t, tree, lib.Root())
+}
+
+func TestParent_Clone(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("a").AddBranch("b").AddBranch("c")
+
+	clone := tree.Clone()
+	c := clone.Branches[0].Branches[0].Branches[0]
+	assert.Same(t, clone, c.Root())
+	assert.Equal(t, []string{"a", "b", "c"}, c.Path())
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	tree := NewTree()
+	usr := tree.AddBranch("usr")
+	local := usr.AddBranch("local")
+	bin := local.AddBranch("bin")
+	lib := local.AddBranch("lib")
+	share := usr.AddBranch("share")
+	etc := tree.AddBranch("etc")
+
+	assert.Same(t, local, LowestCommonAncestor(bin, lib))
+	assert.Same(t, usr, LowestCommonAncestor(bin, share))
+	assert.Same(t, usr, LowestCommonAncestor(share, bin))
+	assert.Same(t, tree, LowestCommonAncestor(bin, etc))
+	assert.Same(t, local, LowestCommonAncestor(local, bin))
+	assert.Same(t, bin, LowestCommonAncestor(bin, bin))
+
+	assert.Nil(t, LowestCommonAncestor(bin, NewTree().AddBranch("other")))
+	assert.Nil(t, LowestCommonAncestor(bin, nil))
+}
+
+func ExampleTree_Path() {
+	tree := NewTree()
+	tree.AddBranch("usr").AddBranch("local").AddBranches("bin", "lib")
+
+	var find func(tree *Tree, label string) *Tree
+	find = func(tree *Tree, label string) *Tree {
+		for _, branch := range tree.Branches {
+			if branch.Label == label {
+				return branch
+			}
+			if found := find(branch, label); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	lib := find(tree, "lib")
+	fmt.Println(lib.Path(), lib.Level())
+	// Output:
+	// [usr local lib] 3
+}
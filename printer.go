@@ -0,0 +1,66 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// printer holds the state of a single call to PrintStyle while the tree is recursively printed
+// with the indented layout
+type printer struct {
+	*visitor
+	config   *printConfig
+	scaffold scaffolding
+	lines    []printLine
+}
+
+// printLine is a single line of output, before it is assembled into text
+type printLine struct {
+	scaffold scaffolding // the style of the branch
+	padding  string      // scaffold inherited from the ancestors of the branch
+	markup   string      // scaffold of the branch itself
+	text     string      // one line of the label
+}
+
+// addLine adds a line of output. Tabs in the text are expanded to line up with the tab stops of
+// the whole line
+func (p *printer) addLine(scaffold scaffolding, padding string, markup string, text string) {
+	text = expandTabs(text, textWidth(padding+markup), p.config.tabWidth)
+	p.lines = append(p.lines, printLine{scaffold: scaffold, padding: padding, markup: markup, text: text})
+}
+
+// scaffoldFor returns the scaffolding of a branch at the given depth, which is the style passed
+// to PrintStyle unless the branch has a style of its own (see WithStyleFunc)
+func (p *printer) scaffoldFor(branch *Tree, depth int) scaffolding {
+	if p.config.styleFunc == nil || depth == 0 {
+		return p.scaffold
+	}
+	style := p.config.styleFunc(p.original(branch), depth)
+	if style < 0 || int(style) >= len(scaffoldingDict) {
+		return p.scaffold
+	}
+	return scaffoldingDict[style]
+}
+
+// String assembles the lines of output into the printed tree
+func (p *printer) String() string {
+	buf := strings.Builder{}
+	if p.config.mirrored {
+		for _, line := range p.mirroredLines() {
+			buf.WriteString(line + "\n")
+		}
+		return buf.String()
+	}
+	for _, line := range p.lines {
+		buf.WriteString(line.colorize(line.padding+line.markup) + line.text + "\n")
+	}
+	return buf.String()
+}
+
+// colorize returns the scaffold in the color of the style of the line. Scaffold that is only
+// spaces is left alone
+func (line printLine) colorize(scaffold string) string {
+	if line.scaffold.color == "" || strings.TrimLeft(scaffold, " ") == "" {
+		return scaffold
+	}
+	return line.scaffold.color + scaffold + ansiReset
+}
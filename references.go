@@ -0,0 +1,119 @@
+package printtree
+
+import (
+	"strings"
+)
+
+// referenceMarker is appended to the first line of the label of a shared branch that has
+// already been printed in full
+const referenceMarker = " (*) see above"
+
+// visitor tracks the branches that have been printed so far during a single call to PrintStyle.
+// It stops the printing at branches that loop back to an ancestor, at collapsed branches, and
+// (with WithBackReferences) at shared branches that have already been printed
+type visitor struct {
+	ancestors ancestry
+	seen      map[interface{}]bool // keys of the branches that have been printed in full
+	decorated map[*Tree]*Tree      // the branches printed with decorated labels, and their originals
+	markers   foldMarkers
+	links     bool // print the URLs of branches as hyperlinks
+	config    *printConfig
+}
+
+// newVisitor returns a visitor for printing the branches of the tree in the style of the scaffold
+func newVisitor(tree *Tree, scaffold scaffolding, config *printConfig) *visitor {
+	markers := boxFoldMarkers
+	if scaffold.lines == asciiLines {
+		markers = asciiFoldMarkers
+	}
+	return &visitor{
+		ancestors: ancestry{tree: true},
+		seen:      make(map[interface{}]bool),
+		decorated: make(map[*Tree]*Tree),
+		markers:   markers,
+		links:     config.hyperlinks == HyperlinksOn || (config.hyperlinks == HyperlinksAuto && terminalHyperlinks()),
+		config:    config,
+	}
+}
+
+// enter returns the branch that should be printed in place of the given branch, and marks it as
+// being printed. Every call to enter must be followed by a call to leave with the returned
+// branch once its branches have been printed
+func (v *visitor) enter(branch *Tree) *Tree {
+	branch = v.ancestors.follow(branch)
+	if v.config.references && len(branch.Branches) > 0 {
+		key := v.key(branch)
+		if v.seen[key] {
+			// only the first line of the label is followed by the marker
+			lines := strings.SplitN(branch.Label, "\n", 2)
+			lines[0] += referenceMarker
+			marker := v.decorate(&Tree{Label: strings.Join(lines, "\n"), Icon: v.icon(branch), URL: branch.URL})
+			v.decorated[marker] = branch
+			return marker
+		}
+		v.seen[key] = true
+	}
+	v.ancestors[branch] = true
+	return v.decorate(branch)
+}
+
+// leave marks the branch as no longer being printed
+func (v *visitor) leave(branch *Tree) {
+	if original, ok := v.decorated[branch]; ok {
+		delete(v.decorated, branch)
+		branch = original
+	}
+	delete(v.ancestors, branch)
+}
+
+// original returns the branch that was passed to enter for a branch that enter returned, so
+// that choices such as the style of a branch are made on the branch in the tree, not a copy
+func (v *visitor) original(branch *Tree) *Tree {
+	if original, ok := v.decorated[branch]; ok {
+		return original
+	}
+	return branch
+}
+
+// decorate returns the branch as it should be printed: without its branches if it is collapsed,
+// and with an icon, hyperlink, fold marker and the number of hidden branches in the label if
+// those options are set. If anything changes, a copy of the branch is returned, which leave maps
+// back to the original
+func (v *visitor) decorate(branch *Tree) *Tree {
+	link := v.links && branch.URL != ""
+	icon := v.icon(branch)
+	if !branch.Collapsed && !v.config.foldMarkers && !link && icon == "" && v.config.iconFunc == nil {
+		return branch
+	}
+
+	lines := strings.Split(branch.Label, "\n")
+	if link {
+		lines = hyperlinkLines(lines, branch.URL)
+	}
+	if icon != "" || v.config.iconFunc != nil {
+		lines = iconLines(lines, icon)
+	}
+	lines = v.foldLines(branch, lines)
+
+	decorated := &Tree{
+		Label:     strings.Join(lines, "\n"),
+		Payload:   branch.Payload,
+		Collapsed: branch.Collapsed,
+		URL:       branch.URL,
+		Icon:      branch.Icon,
+	}
+	if !branch.Collapsed {
+		decorated.Branches = branch.Branches
+	}
+	v.decorated[decorated] = branch
+	return decorated
+}
+
+// key returns the key that identifies a shared branch. Without a custom key, branches are
+// identified by their identity
+func (v *visitor) key(branch *Tree) interface{} {
+	if v.config.referenceKey != nil {
+		return v.config.referenceKey(branch)
+	}
+	return branch
+}
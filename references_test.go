@@ -0,0 +1,114 @@
+package printtree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackReferences(t *testing.T) {
+	logging := NewTree().AddBranch("logging")
+	logging.AddBranch("fmt")
+
+	tree := NewTree()
+	app := tree.AddBranch("app")
+	app.AddBranch("http").AddTreeAsBranch(logging)
+	app.AddBranch("db").AddTreeAsBranch(logging)
+	app.AddTreeAsBranch(logging)
+	app.AddBranch("fmt")
+
+	// shared leaves, such as "fmt", are printed every time
+	assert.Equal(t, `app
+├── http
+│   ╰── logging
+│       ╰── fmt
+├── db
+│   ╰── logging (*) see above
+├── logging (*) see above
+╰── fmt
+`, tree.PrintStyle(BoxStyle, WithBackReferences()))
+
+	assert.Equal(t, `     ╭─ http ─── logging ─── fmt
+app ─┼─ db ─── logging (*) see above
+     ├─ logging (*) see above
+     ╰─ fmt
+`, tree.PrintStyle(BoxStyle, WithBackReferences(), WithLayout(LeftToRightLayout), WithMaxWidth(0)))
+
+	// without the option, shared branches are printed in full every time
+	assert.Equal(t, `app
+├── http
+│   ╰── logging
+│       ╰── fmt
+├── db
+│   ╰── logging
+│       ╰── fmt
+├── logging
+│   ╰── fmt
+╰── fmt
+`, tree.Print())
+}
+
+func TestBackReferenceKey(t *testing.T) {
+	// branches that are equal copies rather than the same branch are matched by their key
+	tree := NewTree()
+	app := tree.AddBranch("app")
+	app.AddBranch("http").AddBranch("logging").AddBranch("fmt")
+	app.AddBranch("db").AddBranch("logging\nv1.2").AddBranch("fmt")
+
+	assert.Equal(t, `app
+├── http
+│   ╰── logging
+│       ╰── fmt
+╰── db
+    ╰── logging
+        v1.2
+        ╰── fmt
+`, tree.PrintStyle(BoxStyle, WithBackReferences()))
+
+	firstLine := func(branch *Tree) string {
+		return strings.SplitN(branch.Label, "\n", 2)[0]
+	}
+	assert.Equal(t, `app
+├── http
+│   ╰── logging
+│       ╰── fmt
+╰── db
+    ╰── logging (*) see above
+        v1.2
+`, tree.PrintStyle(BoxStyle, WithBackReferenceKey(firstLine)))
+}
+
+func TestBackReferences_Cycle(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+
+	assert.Equal(t, `root
+╰── a
+    ├── leaf
+    ╰── b
+        ╰── ↻ (cycle to root)
+`, tree.PrintStyle(BoxStyle, WithBackReferences()))
+}
+
+func ExampleWithBackReferences() {
+	json := NewTree().AddBranch("encoding/json")
+	json.AddBranch("reflect")
+
+	tree := NewTree()
+	app := tree.AddBranch("app")
+	app.AddBranch("net/http").AddTreeAsBranch(json)
+	app.AddTreeAsBranch(json)
+
+	fmt.Print(tree.PrintStyle(BoxStyle, WithBackReferences()))
+	// Output:
+	// app
+	// ├── net/http
+	// │   ╰── encoding/json
+	// │       ╰── reflect
+	// ╰── encoding/json (*) see above
+}
@@ -0,0 +1,142 @@
+package printtree
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Collator compares strings the way a language orders them. The Collator of
+// golang.org/x/text/collate satisfies this interface
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+// LabelLess orders branches by their labels, character by character. This is the order used by
+// Sort and DeepSort
+func LabelLess(branch1, branch2 *Tree) bool {
+	return branch1.Label < branch2.Label
+}
+
+// NaturalLess orders branches by their labels the way a person would, comparing runs of digits
+// by their numeric value. So "file2" comes before "file10", and "v1.9" before "v1.10"
+func NaturalLess(branch1, branch2 *Tree) bool {
+	return naturalCompare(branch1.Label, branch2.Label) < 0
+}
+
+// FoldLess orders branches by their labels, ignoring differences in case. Labels that only differ
+// in case are ordered character by character
+func FoldLess(branch1, branch2 *Tree) bool {
+	lower1, lower2 := strings.ToLower(branch1.Label), strings.ToLower(branch2.Label)
+	if lower1 != lower2 {
+		return lower1 < lower2
+	}
+	return branch1.Label < branch2.Label
+}
+
+// CollatorLess returns a BranchLess that orders branches by their labels with the collator, for
+// ordering in the conventions of a language. For example, with golang.org/x/text
+//   tree.DeepSortCustom(CollatorLess(collate.New(language.German)))
+func CollatorLess(collator Collator) BranchLess {
+	return func(branch1, branch2 *Tree) bool {
+		return collator.CompareString(branch1.Label, branch2.Label) < 0
+	}
+}
+
+// LeavesFirst orders branches without branches of their own before branches that have some, like
+// files before directories. Combine it with another order using Chain
+func LeavesFirst(branch1, branch2 *Tree) bool {
+	return len(branch1.Branches) == 0 && len(branch2.Branches) > 0
+}
+
+// LeavesLast orders branches that have branches of their own before branches without any, like
+// directories before files. Combine it with another order using Chain
+func LeavesLast(branch1, branch2 *Tree) bool {
+	return len(branch1.Branches) > 0 && len(branch2.Branches) == 0
+}
+
+// SizeLess orders branches by the number of branches below them (see Count), smallest first
+func SizeLess(branch1, branch2 *Tree) bool {
+	return branch1.Count() < branch2.Count()
+}
+
+// DepthLess orders branches by their depth (see Depth), shallowest first
+func DepthLess(branch1, branch2 *Tree) bool {
+	return branch1.Depth() < branch2.Depth()
+}
+
+// Chain returns a BranchLess that orders branches by the first order, then orders branches that
+// are equal in the first order by the second order, and so on. For example, directories first,
+// each group in natural order
+//   tree.DeepSortCustom(Chain(LeavesLast, NaturalLess))
+func Chain(orders ...BranchLess) BranchLess {
+	return func(branch1, branch2 *Tree) bool {
+		for _, less := range orders {
+			switch {
+			case less(branch1, branch2):
+				return true
+			case less(branch2, branch1):
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// Reverse returns a BranchLess that orders branches in the opposite order. For example, largest
+// subtrees first
+//   tree.DeepSortCustom(Reverse(SizeLess))
+func Reverse(less BranchLess) BranchLess {
+	return func(branch1, branch2 *Tree) bool {
+		return less(branch2, branch1)
+	}
+}
+
+// naturalCompare compares two strings, treating runs of digits as numbers. Returns a negative
+// number if a comes before b, a positive number if it comes after and 0 if they are equal
+func naturalCompare(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		digitsA, digitsB := digitRun(a), digitRun(b)
+		if digitsA > 0 && digitsB > 0 {
+			if result := compareNumbers(a[:digitsA], b[:digitsB]); result != 0 {
+				return result
+			}
+			a, b = a[digitsA:], b[digitsB:]
+			continue
+		}
+
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		if runeA != runeB {
+			if runeA < runeB {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return len(a) - len(b)
+}
+
+// digitRun returns the number of digits at the start of the string
+func digitRun(s string) int {
+	for index, r := range s {
+		if r > unicode.MaxASCII || !unicode.IsDigit(r) {
+			return index
+		}
+	}
+	return len(s)
+}
+
+// compareNumbers compares two runs of digits by their numeric value. Numbers with the same value
+// are ordered with the fewest leading zeros first
+func compareNumbers(a, b string) int {
+	trimmedA, trimmedB := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	switch {
+	case len(trimmedA) != len(trimmedB):
+		return len(trimmedA) - len(trimmedB)
+	case trimmedA != trimmedB:
+		return strings.Compare(trimmedA, trimmedB)
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,122 @@
+package printtree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// labels returns the labels of the branches of the tree
+func labels(tree *Tree) []string {
+	result := make([]string, 0, len(tree.Branches))
+	for _, branch := range tree.Branches {
+		result = append(result, branch.Label)
+	}
+	return result
+}
+
+// sortedLabels returns the labels sorted with the less function
+func sortedLabels(less BranchLess, names ...string) []string {
+	tree := NewTree()
+	tree.AddBranches(names...)
+	tree.SortCustom(less)
+	return labels(tree)
+}
+
+func TestNaturalLess(t *testing.T) {
+	assert.Equal(t, []string{"file1", "file2", "file10", "file20", "file100"},
+		sortedLabels(NaturalLess, "file10", "file2", "file100", "file1", "file20"))
+	assert.Equal(t, []string{"v1.2", "v1.9", "v1.10", "v1.10.1", "v2.0"},
+		sortedLabels(NaturalLess, "v1.10", "v2.0", "v1.9", "v1.10.1", "v1.2"))
+	assert.Equal(t, []string{"7", "07", "007", "8"},
+		sortedLabels(NaturalLess, "007", "8", "07", "7"))
+	assert.Equal(t, []string{"a", "a1", "ab", "b"},
+		sortedLabels(NaturalLess, "b", "ab", "a1", "a"))
+}
+
+func TestFoldLess(t *testing.T) {
+	assert.Equal(t, []string{"apple", "Banana", "banana", "cherry"},
+		sortedLabels(FoldLess, "cherry", "banana", "Banana", "apple"))
+	assert.Equal(t, []string{"Banana", "apple", "banana", "cherry"},
+		sortedLabels(LabelLess, "cherry", "banana", "Banana", "apple"))
+}
+
+// accentCollator is a collator that ignores a few accents
+type accentCollator struct{}
+
+func (accentCollator) CompareString(a, b string) int {
+	fold := strings.NewReplacer("é", "e", "è", "e", "ö", "o")
+	return strings.Compare(fold.Replace(a), fold.Replace(b))
+}
+
+func TestCollatorLess(t *testing.T) {
+	assert.Equal(t, []string{"eclair", "élan", "ezra", "zoe"},
+		sortedLabels(CollatorLess(accentCollator{}), "zoe", "ezra", "élan", "eclair"))
+}
+
+func TestLeaves(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("zeta.txt")
+	big := tree.AddBranch("big")
+	big.AddBranch("one").AddBranch("deep").AddBranch("deeper")
+	big.AddBranches("two", "three")
+	tree.AddBranch("alpha.txt")
+	tree.AddBranch("small").AddBranch("only")
+
+	tree.SortCustom(Chain(LeavesFirst, LabelLess))
+	assert.Equal(t, []string{"alpha.txt", "zeta.txt", "big", "small"}, labels(tree))
+
+	tree.SortCustom(Chain(LeavesLast, LabelLess))
+	assert.Equal(t, []string{"big", "small", "alpha.txt", "zeta.txt"}, labels(tree))
+}
+
+func TestSizeAndDepth(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("zeta.txt")
+	big := tree.AddBranch("big")
+	big.AddBranch("one").AddBranch("deep").AddBranch("deeper")
+	big.AddBranches("two", "three")
+	tree.AddBranch("alpha.txt")
+	tree.AddBranch("small").AddBranch("only")
+
+	tree.SortCustom(Chain(Reverse(SizeLess), LabelLess))
+	assert.Equal(t, []string{"big", "small", "alpha.txt", "zeta.txt"}, labels(tree))
+
+	tree.SortCustom(Chain(DepthLess, Reverse(LabelLess)))
+	assert.Equal(t, []string{"zeta.txt", "alpha.txt", "small", "big"}, labels(tree))
+}
+
+func TestChain(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("zeta.txt")
+	big := tree.AddBranch("big")
+	big.AddBranch("one").AddBranch("deep").AddBranch("deeper")
+	big.AddBranches("two", "three")
+	tree.AddBranch("alpha.txt")
+	tree.AddBranch("small").AddBranch("only")
+
+	// an empty chain keeps the order
+	tree.SortCustom(Chain())
+	assert.Equal(t, []string{"zeta.txt", "big", "alpha.txt", "small"}, labels(tree))
+}
+
+func ExampleChain() {
+	tree := NewTree()
+	tree.AddBranch("notes10.txt")
+	tree.AddBranch("src").AddBranches("main.go")
+	tree.AddBranch("notes9.txt")
+	tree.AddBranch("Docs").AddBranches("guide.md")
+
+	// directories first, then in natural order
+	tree.DeepSortCustom(Chain(LeavesLast, NaturalLess))
+	fmt.Print(tree.Print())
+	// Output:
+	// Docs
+	// ╰── guide.md
+	// src
+	// ╰── main.go
+	// notes9.txt
+	// notes10.txt
+}
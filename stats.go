@@ -0,0 +1,201 @@
+package printtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Count returns the number of branches in the tree, at any depth. The tree itself is not
+// counted, so a tree with no branches has a count of 0. Like Depth, a branch that is shared by
+// several parents is counted once for every parent
+func (tree *Tree) Count() int {
+	count := 0
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		count++
+	})
+	return count
+}
+
+// LeafCount returns the number of branches in the tree that have no branches of their own
+func (tree *Tree) LeafCount() int {
+	count := 0
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		if len(branch.Branches) == 0 {
+			count++
+		}
+	})
+	return count
+}
+
+// Widths returns the number of branches at each depth of the tree. The tree itself is at depth
+// 0, so the first width is always 1 and there are Depth()+1 widths
+func (tree *Tree) Widths() []int {
+	widths := []int{1}
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		if depth >= len(widths) {
+			widths = append(widths, 0)
+		}
+		widths[depth]++
+	})
+	return widths
+}
+
+// MaxBranching returns the largest number of branches that any branch in the tree (including
+// the tree itself) has
+func (tree *Tree) MaxBranching() int {
+	max := len(tree.Branches)
+	tree.walk(ancestry{tree: true}, 0, func(branch *Tree, depth int) {
+		if len(branch.Branches) > max {
+			max = len(branch.Branches)
+		}
+	})
+	return max
+}
+
+// walk calls fn for every branch below this tree, parents before their branches. The depth of
+// the branches of this tree is depth+1
+func (tree *Tree) walk(ancestors ancestry, depth int, fn func(branch *Tree, depth int)) {
+	for _, branch := range tree.Branches {
+		branch = ancestors.follow(branch)
+		fn(branch, depth+1)
+		ancestors[branch] = true
+		branch.walk(ancestors, depth+1, fn)
+		delete(ancestors, branch)
+	}
+}
+
+// RollUpFunc computes the value of a branch from the branch itself and the values that have
+// already been computed for its branches, in the same order as its branches
+type RollUpFunc func(branch *Tree, values []interface{}) interface{}
+
+// RollUp computes a value for every branch of the tree, from the bottom up, and returns the
+// values of all branches, including the tree itself. A branch that is shared by several parents
+// is only computed once, and a branch that loops back to one of its ancestors is left out of
+// the values of its parent. For example, to find the newest modification time in each
+// directory
+//   newest := tree.RollUp(func(branch *Tree, values []interface{}) interface{} {
+//       latest := branch.Payload.(os.FileInfo).ModTime()
+//       for _, value := range values {
+//           if value.(time.Time).After(latest) {
+//               latest = value.(time.Time)
+//           }
+//       }
+//       return latest
+//   })
+func (tree *Tree) RollUp(fn RollUpFunc) map[*Tree]interface{} {
+	results := make(map[*Tree]interface{})
+	tree.rollUp(fn, results, ancestry{})
+	return results
+}
+
+// rollUp is the internal, recursive hook for RollUp
+func (tree *Tree) rollUp(fn RollUpFunc, results map[*Tree]interface{}, ancestors ancestry) interface{} {
+	if value, ok := results[tree]; ok {
+		return value
+	}
+
+	ancestors[tree] = true
+	values := make([]interface{}, 0, len(tree.Branches))
+	for _, branch := range tree.Branches {
+		if ancestors[branch] {
+			continue
+		}
+		values = append(values, branch.rollUp(fn, results, ancestors))
+	}
+	delete(ancestors, tree)
+
+	value := fn(tree, values)
+	results[tree] = value
+	return value
+}
+
+// ValueFunc returns the value that a branch contributes to the totals of itself and its
+// ancestors, usually taken from its payload. For example, the size of a file
+type ValueFunc func(branch *Tree) float64
+
+// Totals returns the value of every branch added to the values of all the branches below it,
+// such as the size of each directory in a tree of files
+func (tree *Tree) Totals(value ValueFunc) map[*Tree]float64 {
+	rolledUp := tree.RollUp(func(branch *Tree, values []interface{}) interface{} {
+		total := value(branch)
+		for _, v := range values {
+			total += v.(float64)
+		}
+		return total
+	})
+
+	totals := make(map[*Tree]float64, len(rolledUp))
+	for branch, total := range rolledUp {
+		totals[branch] = total.(float64)
+	}
+	return totals
+}
+
+// AnnotationFunc returns the text that is added to the label of a branch to show its total, and
+// the percentage (0 to 100) of the total of the whole tree that it makes up
+type AnnotationFunc func(branch *Tree, total float64, percent float64) string
+
+// DefaultAnnotation annotates a label with the total and percentage, like "src (1234, 56%)"
+func DefaultAnnotation(branch *Tree, total float64, percent float64) string {
+	return fmt.Sprintf(" (%g, %.0f%%)", total, percent)
+}
+
+// ByteSizeAnnotation annotates a label with a total that is a number of bytes, shown the way du
+// does, like "src (1.2K, 56%)"
+func ByteSizeAnnotation(branch *Tree, total float64, percent float64) string {
+	return fmt.Sprintf(" (%s, %.0f%%)", byteSize(total), percent)
+}
+
+// AnnotateTotals computes the totals of the tree (see Totals) and adds them to the labels of
+// the branches with the annotation function. If the annotation function is nil,
+// DefaultAnnotation is used. For a disk usage report
+//   tree.AnnotateTotals(func(branch *Tree) float64 {
+//       if info, ok := branch.Payload.(os.FileInfo); ok {
+//           return float64(info.Size())
+//       }
+//       return 0
+//   }, ByteSizeAnnotation)
+// prints like
+//   project (12K, 100%)
+//   ├── src (10K, 83%)
+//   │   ╰── main.go (10K, 83%)
+//   ╰── README.md (2.0K, 17%)
+// The annotation is added to the first line of each label. The label of the tree itself is
+// only annotated if it has one
+func (tree *Tree) AnnotateTotals(value ValueFunc, annotation AnnotationFunc) {
+	if annotation == nil {
+		annotation = DefaultAnnotation
+	}
+	totals := tree.Totals(value)
+	whole := totals[tree]
+
+	for branch, total := range totals {
+		if branch == tree && tree.Label == "" {
+			continue
+		}
+		percent := 0.0
+		if whole != 0 {
+			percent = 100 * total / whole
+		}
+		lines := strings.SplitN(branch.Label, "\n", 2)
+		lines[0] += annotation(branch, total, percent)
+		branch.Label = strings.Join(lines, "\n")
+	}
+}
+
+// byteSize formats a number of bytes with a binary unit suffix, like du -h
+func byteSize(bytes float64) string {
+	const units = "KMGTPE"
+	if bytes < 1024 {
+		return fmt.Sprintf("%.0fB", bytes)
+	}
+	unit := -1
+	for bytes >= 1024 && unit < len(units)-1 {
+		bytes /= 1024
+		unit++
+	}
+	if bytes < 10 {
+		return fmt.Sprintf("%.1f%c", bytes, units[unit])
+	}
+	return fmt.Sprintf("%.0f%c", bytes, units[unit])
+}
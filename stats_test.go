@@ -0,0 +1,165 @@
+package printtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fileSize returns the size in the payload of a file
+func fileSize(branch *Tree) float64 {
+	if size, ok := branch.Payload.(int); ok {
+		return float64(size)
+	}
+	return 0
+}
+
+func TestCount(t *testing.T) {
+	tree := NewTree()
+	project := tree.AddBranch("project")
+	src := project.AddBranch("src")
+	src.AddBranches("main.go", "util.go")
+	project.AddBranch("README.md")
+	project.AddBranch("empty")
+
+	assert.Equal(t, 6, tree.Count())
+	assert.Equal(t, 4, tree.LeafCount())
+	assert.Equal(t, []int{1, 1, 3, 2}, tree.Widths())
+	assert.Equal(t, 3, tree.MaxBranching())
+
+	empty := NewTree()
+	assert.Equal(t, 0, empty.Count())
+	assert.Equal(t, 0, empty.LeafCount())
+	assert.Equal(t, []int{1}, empty.Widths())
+	assert.Equal(t, 0, empty.MaxBranching())
+
+	// the loop is counted as a leaf, the way it is printed
+	cyclic := NewTree()
+	root := cyclic.AddBranch("root")
+	a := root.AddBranch("a")
+	a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+	assert.Equal(t, 5, cyclic.Count())
+	assert.Equal(t, 2, cyclic.LeafCount())
+	assert.Equal(t, []int{1, 1, 1, 2, 1}, cyclic.Widths())
+}
+
+func TestRollUp(t *testing.T) {
+	tree := NewTree()
+	project := tree.AddBranch("project")
+	src := project.AddBranch("src")
+	src.AddBranch("main.go").Payload = 8192
+	src.AddBranch("util.go").Payload = 2048
+	project.AddBranch("README.md").Payload = 2048
+	project.AddBranch("empty")
+
+	// count the files below each branch
+	files := tree.RollUp(func(branch *Tree, values []interface{}) interface{} {
+		count := 0
+		if branch.Payload != nil {
+			count = 1
+		}
+		for _, value := range values {
+			count += value.(int)
+		}
+		return count
+	})
+	assert.Equal(t, 3, files[tree])
+	assert.Equal(t, 2, files[src])
+	assert.Equal(t, 0, files[project.Branches[2]])
+	assert.Len(t, files, 7)
+}
+
+func TestTotals(t *testing.T) {
+	tree := NewTree()
+	project := tree.AddBranch("project")
+	src := project.AddBranch("src")
+	src.AddBranch("main.go").Payload = 8192
+	src.AddBranch("util.go").Payload = 2048
+	project.AddBranch("README.md").Payload = 2048
+	project.AddBranch("empty")
+
+	totals := tree.Totals(fileSize)
+
+	assert.Equal(t, 12288.0, totals[tree])
+	assert.Equal(t, 12288.0, totals[project])
+	assert.Equal(t, 10240.0, totals[project.Branches[0]])
+	assert.Equal(t, 2048.0, totals[project.Branches[1]])
+	assert.Equal(t, 0.0, totals[project.Branches[2]])
+
+	// a loop does not count twice
+	cyclic := NewTree()
+	root := cyclic.AddBranch("root")
+	a := root.AddBranch("a")
+	leaf := a.AddBranch("leaf")
+	a.AddBranch("b").AddTreeAsBranch(root)
+	leaf.Payload = 1
+	assert.Equal(t, 1.0, cyclic.Totals(fileSize)[cyclic])
+}
+
+func TestAnnotateTotals(t *testing.T) {
+	tree := NewTree()
+	project := tree.AddBranch("project")
+	src := project.AddBranch("src")
+	src.AddBranch("main.go").Payload = 8192
+	src.AddBranch("util.go").Payload = 2048
+	project.AddBranch("README.md").Payload = 2048
+	project.AddBranch("empty")
+
+	tree.AnnotateTotals(fileSize, nil)
+
+	assert.Equal(t, `project (12288, 100%)
+├── src (10240, 83%)
+│   ├── main.go (8192, 67%)
+│   ╰── util.go (2048, 17%)
+├── README.md (2048, 17%)
+╰── empty (0, 0%)
+`, tree.Print())
+
+	// percentages are of the tree that is annotated
+	tree = NewTree()
+	project = tree.AddBranch("project")
+	src = project.AddBranch("src")
+	src.AddBranch("main.go").Payload = 8192
+	src.AddBranch("util.go").Payload = 2048
+	project.AddBranch("README.md").Payload = 2048
+	project.AddBranch("empty")
+	src.AnnotateTotals(fileSize, ByteSizeAnnotation)
+	assert.Equal(t, `project
+├── src (10K, 100%)
+│   ├── main.go (8.0K, 80%)
+│   ╰── util.go (2.0K, 20%)
+├── README.md
+╰── empty
+`, tree.Print())
+}
+
+func TestByteSize(t *testing.T) {
+	assert.Equal(t, "0B", byteSize(0))
+	assert.Equal(t, "1023B", byteSize(1023))
+	assert.Equal(t, "1.0K", byteSize(1024))
+	assert.Equal(t, "1.5K", byteSize(1536))
+	assert.Equal(t, "12K", byteSize(12345))
+	assert.Equal(t, "1.0M", byteSize(1<<20))
+	assert.Equal(t, "3.0G", byteSize(3<<30))
+}
+
+func ExampleTree_AnnotateTotals() {
+	tree := NewTree()
+	project := tree.AddBranch("project")
+	project.AddBranch("main.go").Payload = 3000
+	project.AddBranch("README.md").Payload = 1000
+
+	tree.AnnotateTotals(func(branch *Tree) float64 {
+		if size, ok := branch.Payload.(int); ok {
+			return float64(size)
+		}
+		return 0
+	}, ByteSizeAnnotation)
+	fmt.Print(tree.Print())
+	// Output:
+	// project (3.9K, 100%)
+	// ├── main.go (2.9K, 75%)
+	// ╰── README.md (1000B, 25%)
+}
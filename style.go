@@ -0,0 +1,90 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownStyle is returned when a style name or number does not match any style
+var ErrUnknownStyle = errors.New("unknown style")
+
+// ErrDuplicateStyle is returned when a custom style is given a name that another style already
+// has
+var ErrDuplicateStyle = errors.New("duplicate style name")
+
+// StyleByName returns the style with the given name. Every built-in style has a name, such as
+// "box", "box-bold", "ascii-narrow", "bullet" or "roman-uc", and custom styles can be given a
+// name with SetStyleName. Names are not case sensitive
+func StyleByName(name string) (TreeStyle, error) {
+	for index, scaffold := range scaffoldingDict {
+		if scaffold.name != "" && strings.EqualFold(scaffold.name, name) {
+			return TreeStyle(index), nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknownStyle, name)
+}
+
+// StyleNames returns the names of all styles that have a name, in the order the styles were
+// added
+func StyleNames() []string {
+	names := make([]string, 0, len(scaffoldingDict))
+	for _, scaffold := range scaffoldingDict {
+		if scaffold.name != "" {
+			names = append(names, scaffold.name)
+		}
+	}
+	return names
+}
+
+// SetStyleName gives a custom style a name, so that it can be found with StyleByName. For
+// example
+//   myStyle := AddListStyle("  ", "(1) ")
+//   err := SetStyleName(myStyle, "parens")
+// The name must not be empty or be the name of another style. A style can be renamed, but a
+// built-in style can not
+func SetStyleName(style TreeStyle, name string) error {
+	if style < 0 || int(style) >= len(scaffoldingDict) {
+		return fmt.Errorf("%w %d", ErrUnknownStyle, style)
+	}
+	if style <= RomanUCStyle {
+		return fmt.Errorf("built-in style %q can not be renamed", scaffoldingDict[style].name)
+	}
+	if name == "" {
+		return errors.New("style name is empty")
+	}
+	if existing, err := StyleByName(name); err == nil && existing != style {
+		return fmt.Errorf("%w %q", ErrDuplicateStyle, name)
+	}
+	scaffoldingDict[style].name = name
+	return nil
+}
+
+// String returns the name of the style, or "TreeStyle(n)" if the style has no name
+func (style TreeStyle) String() string {
+	if style >= 0 && int(style) < len(scaffoldingDict) && scaffoldingDict[style].name != "" {
+		return scaffoldingDict[style].name
+	}
+	return "TreeStyle(" + strconv.Itoa(int(style)) + ")"
+}
+
+// MarshalText returns the name of the style, so that styles can be written to configuration
+// files. Styles without a name can not be marshaled
+func (style TreeStyle) MarshalText() ([]byte, error) {
+	if style < 0 || int(style) >= len(scaffoldingDict) || scaffoldingDict[style].name == "" {
+		return nil, fmt.Errorf("%w %d", ErrUnknownStyle, style)
+	}
+	return []byte(scaffoldingDict[style].name), nil
+}
+
+// UnmarshalText sets the style to the style with the given name, so that styles can be read from
+// configuration files and command line flags
+func (style *TreeStyle) UnmarshalText(text []byte) error {
+	found, err := StyleByName(string(text))
+	if err != nil {
+		return err
+	}
+	*style = found
+	return nil
+}
@@ -0,0 +1,225 @@
+package printtree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleByName(t *testing.T) {
+	for _, name := range StyleNames() {
+		style, err := StyleByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, style.String())
+	}
+
+	style, err := StyleByName("Roman-UC")
+	assert.NoError(t, err)
+	assert.Equal(t, RomanUCStyle, style)
+
+	_, err = StyleByName("fancy")
+	assert.True(t, errors.Is(err, ErrUnknownStyle))
+	assert.EqualError(t, err, `unknown style "fancy"`)
+
+	assert.Equal(t, []string{"ascii", "box", "box-bold"}, StyleNames()[:3])
+}
+
+func TestStyleString(t *testing.T) {
+	assert.Equal(t, "box", BoxStyle.String())
+	assert.Equal(t, "box-bold-narrow", fmt.Sprint(BoxBoldNarrowStyle))
+	assert.Equal(t, "TreeStyle(-1)", TreeStyle(-1).String())
+	assert.Equal(t, "TreeStyle(1000)", TreeStyle(1000).String())
+}
+
+// forgetStyleNames removes the names of the styles from the registry when the test ends, so
+// the names can be used again when the tests run more than once
+func forgetStyleNames(t *testing.T, styles ...TreeStyle) {
+	t.Cleanup(func() {
+		for _, style := range styles {
+			scaffoldingDict[style].name = ""
+		}
+	})
+}
+
+func TestSetStyleName(t *testing.T) {
+	style := AddListStyle("  ", "(1) ")
+	other := AddStructuralStyle("+- ", "`- ", "|  ", "   ")
+	forgetStyleNames(t, style, other)
+	assert.Equal(t, fmt.Sprintf("TreeStyle(%d)", style), style.String())
+
+	assert.NoError(t, SetStyleName(style, "test-parens"))
+	assert.Equal(t, "test-parens", style.String())
+	found, err := StyleByName("test-parens")
+	assert.NoError(t, err)
+	assert.Equal(t, style, found)
+
+	// renaming keeps the style
+	assert.NoError(t, SetStyleName(style, "test-parens-renamed"))
+	_, err = StyleByName("test-parens")
+	assert.Error(t, err)
+
+	assert.True(t, errors.Is(SetStyleName(other, "test-parens-renamed"), ErrDuplicateStyle))
+	assert.True(t, errors.Is(SetStyleName(other, "box"), ErrDuplicateStyle))
+	assert.Error(t, SetStyleName(other, ""))
+	assert.EqualError(t, SetStyleName(BoxStyle, "my-box"), `built-in style "box" can not be renamed`)
+	assert.True(t, errors.Is(SetStyleName(TreeStyle(1000), "nope"), ErrUnknownStyle))
+}
+
+func TestStyleText(t *testing.T) {
+	config := struct {
+		Style TreeStyle `json:"style"`
+	}{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"style": "bullet"}`), &config))
+	assert.Equal(t, BulletStyle, config.Style)
+
+	err := json.Unmarshal([]byte(`{"style": "fancy"}`), &config)
+	assert.True(t, errors.Is(err, ErrUnknownStyle))
+
+	config.Style = AlphaUCStyle
+	data, err := json.Marshal(config)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"style":"alpha-uc"}`, string(data))
+
+	config.Style = TreeStyle(1000)
+	_, err = json.Marshal(config)
+	assert.Error(t, err)
+}
+
+func ExampleStyleByName() {
+	tree := NewTree()
+	tree.AddBranch("root").AddBranches("one", "two")
+
+	style, err := StyleByName("ascii")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(tree.PrintStyle(style))
+	// Output:
+	// root
+	// |-- one
+	// '-- two
+}
+
+func TestWithDepthStyles(t *testing.T) {
+	tree := NewTree()
+	report := tree.AddBranch("Report")
+	intro := report.AddBranch("Introduction")
+	intro.AddBranch("Scope").AddBranches("in", "out")
+	intro.AddBranch("Goals")
+	results := report.AddBranch("Results")
+	results.AddBranch("Speed").AddBranch("fast")
+	results.AddBranch("Size")
+
+	assert.Equal(t, `Report
+ 1. Introduction
+    ├── Scope
+    │   ├── in
+    │   ╰── out
+    ╰── Goals
+ 2. Results
+    ├── Speed
+    │   ╰── fast
+    ╰── Size
+`, tree.PrintStyle(BoxStyle, WithDepthStyles(NumberStyle, BoxStyle)))
+
+	assert.Equal(t, `Report
+ 1. Introduction
+    |-- Scope
+    |   ├── in
+    |   ╰── out
+    '-- Goals
+ 2. Results
+    |-- Speed
+    |   ╰── fast
+    '-- Size
+`, tree.PrintStyle(BoxStyle, WithDepthStyles(NumberStyle, ASCIIStyle, BoxStyle)))
+
+	assert.Equal(t, `        ╭── in
+        ├── out
+    ╭── Scope
+    ├── Goals
+ 1. Introduction
+        ╭── fast
+    ╭── Speed
+    ├── Size
+ 2. Results
+Report
+`, tree.PrintStyle(BoxStyle, WithDepthStyles(NumberStyle, BoxStyle), WithInverted()))
+
+	// no styles is the same as one style
+	assert.Equal(t, tree.Print(), tree.PrintStyle(BoxStyle, WithDepthStyles()))
+}
+
+func TestWithStyleFunc(t *testing.T) {
+	tree := NewTree()
+	report := tree.AddBranch("Report")
+	intro := report.AddBranch("Introduction")
+	intro.AddBranch("Scope").AddBranches("in", "out")
+	intro.AddBranch("Goals")
+	results := report.AddBranch("Results")
+	results.AddBranch("Speed").AddBranch("fast")
+	results.AddBranch("Size")
+
+	// the results, and everything below them, in ASCII
+	inResults := func(branch *Tree, level int) TreeStyle {
+		for ancestor := branch; ancestor != nil; ancestor = ancestor.Parent() {
+			if ancestor == results {
+				return ASCIIStyle
+			}
+		}
+		return -1
+	}
+	assert.Equal(t, `Report
+├── Introduction
+│   ├── Scope
+│   │   ├── in
+│   │   ╰── out
+│   ╰── Goals
+'-- Results
+    |-- Speed
+    |   '-- fast
+    '-- Size
+`, tree.PrintStyle(BoxStyle, WithStyleFunc(inResults)))
+
+	// the style function is given the branches of the tree, not the copies that are printed
+	// with fold markers, icons or hyperlinks
+	results.URL = "https://example.com/results"
+	assert.Equal(t, "▾    Report\n"+
+		"├── ▾    Introduction\n"+
+		"│   ├── ▾    Scope\n"+
+		"│   │   ├──      in\n"+
+		"│   │   ╰──      out\n"+
+		"│   ╰──      Goals\n"+
+		"'-- ▾    "+hyperlink("Results", "https://example.com/results")+"\n"+
+		"    |-- ▾    Speed\n"+
+		"    |   '--      fast\n"+
+		"    '--      Size\n",
+		tree.PrintStyle(BoxStyle, WithStyleFunc(inResults), WithFoldMarkers(), WithHyperlinks(HyperlinksOn),
+			WithIconFunc(func(branch *Tree) string { return "" })))
+
+	levels := []int{}
+	tree.PrintStyle(BoxStyle, WithStyleFunc(func(branch *Tree, level int) TreeStyle {
+		levels = append(levels, level)
+		return BoxStyle
+	}))
+	assert.Equal(t, []int{1, 2, 3, 3, 2, 1, 2, 3, 2}, levels)
+}
+
+func ExampleWithDepthStyles() {
+	tree := NewTree()
+	guide := tree.AddBranch("Guide")
+	guide.AddBranch("Install").AddBranches("Linux", "macOS")
+	guide.AddBranch("Usage").AddBranch("Flags")
+
+	fmt.Print(tree.PrintStyle(BoxStyle, WithDepthStyles(NumberStyle, BoxStyle)))
+	// Output:
+	// Guide
+	//  1. Install
+	//     ├── Linux
+	//     ╰── macOS
+	//  2. Usage
+	//     ╰── Flags
+}
@@ -0,0 +1,294 @@
+package printtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidStyle is returned when a style specification can not be turned into a style
+var ErrInvalidStyle = errors.New("invalid style")
+
+// StyleSpec describes a custom style, so that styles can be kept in configuration files and
+// loaded at runtime with LoadStyles. A structural style sets the Middle, Last, Bypass and None
+// glyphs (see AddStructuralStyle), and a list style sets the Indent and the Bullets (see
+// AddListStyle, including how bullets with 1, a, A, i or I are numbered). The scaffold can be
+// printed in a Color, which is one of the names in the colors table or a raw ANSI SGR code such
+// as "38;5;208"
+type StyleSpec struct {
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type"` // "structural" or "list"
+	Middle  string   `json:"middle,omitempty"`
+	Last    string   `json:"last,omitempty"`
+	Bypass  string   `json:"bypass,omitempty"`
+	None    string   `json:"none,omitempty"`
+	Indent  string   `json:"indent,omitempty"`
+	Bullets []string `json:"bullets,omitempty"`
+	Color   string   `json:"color,omitempty"`
+}
+
+// the types of style
+const (
+	structuralType = "structural"
+	listType       = "list"
+)
+
+// colors are the names of the colors that a scaffold can be printed in, and their ANSI SGR codes
+var colors = map[string]string{
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"gray":    "90",
+	"bold":    "1",
+	"dim":     "2",
+}
+
+// LoadStyles adds the styles described in a specification and returns them. The specification
+// is either JSON (a StyleSpec object, or a list of them) or text, with one "key: value" per line
+// and a blank line between styles
+//   name: arrows
+//   type: structural
+//   middle: "|>- "
+//   last: " `- "
+//   bypass: "|   "
+//   none: "    "
+//   color: blue
+//
+//   name: parens
+//   type: list
+//   indent: "    "
+//   bullets: "(1) " "(a) " "(i) "
+// Values may be quoted like Go strings, which is needed to keep spaces at either end. Every
+// style is validated (see StyleSpec.Validate) before any of them is added
+func LoadStyles(spec []byte) ([]TreeStyle, error) {
+	specs, err := parseStyleSpecs(spec)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if err := spec.Validate(); err != nil {
+			return nil, err
+		}
+		if spec.Name == "" {
+			continue
+		}
+		if _, err := StyleByName(spec.Name); err == nil || names[strings.ToLower(spec.Name)] {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateStyle, spec.Name)
+		}
+		names[strings.ToLower(spec.Name)] = true
+	}
+
+	styles := make([]TreeStyle, 0, len(specs))
+	for _, spec := range specs {
+		style, err := AddStyle(spec)
+		if err != nil {
+			return styles, err
+		}
+		styles = append(styles, style)
+	}
+	return styles, nil
+}
+
+// AddStyle validates the specification and adds the style it describes. If the specification
+// has a name, the style can be found with StyleByName
+func AddStyle(spec StyleSpec) (TreeStyle, error) {
+	if err := spec.Validate(); err != nil {
+		return 0, err
+	}
+	if spec.Name != "" {
+		if _, err := StyleByName(spec.Name); err == nil {
+			return 0, fmt.Errorf("%w %q", ErrDuplicateStyle, spec.Name)
+		}
+	}
+
+	var style TreeStyle
+	if spec.Type == listType {
+		style = AddListStyle(spec.Indent, spec.Bullets...)
+	} else {
+		style = AddStructuralStyle(spec.Middle, spec.Last, spec.Bypass, spec.None)
+	}
+	scaffoldingDict[style].name = spec.Name
+	if spec.Color != "" {
+		scaffoldingDict[style].color = "\x1b[" + colorCode(spec.Color) + "m"
+	}
+	return style, nil
+}
+
+// Validate checks that the specification describes a usable style. All glyphs of a structural
+// style, and the indent and bullets of a list style, must be the same width, or the branches of
+// the tree would not line up
+func (spec StyleSpec) Validate() error {
+	name := spec.Name
+	if name == "" {
+		name = "unnamed"
+	}
+
+	var glyphs, names []string
+	switch spec.Type {
+	case structuralType:
+		glyphs = []string{spec.Middle, spec.Last, spec.Bypass, spec.None}
+		names = []string{"middle", "last", "bypass", "none"}
+	case listType:
+		if len(spec.Bullets) == 0 {
+			return fmt.Errorf("%w %s: a list needs at least one bullet", ErrInvalidStyle, name)
+		}
+		glyphs = append([]string{spec.Indent}, spec.Bullets...)
+		names = []string{"indent"}
+		for index := range spec.Bullets {
+			names = append(names, fmt.Sprintf("bullet %d", index+1))
+		}
+	default:
+		return fmt.Errorf("%w %s: unknown type %q, must be %q or %q", ErrInvalidStyle, name, spec.Type, structuralType, listType)
+	}
+
+	width := textWidth(glyphs[0])
+	if width == 0 {
+		return fmt.Errorf("%w %s: %s is empty", ErrInvalidStyle, name, names[0])
+	}
+	for index, glyph := range glyphs {
+		if strings.ContainsAny(glyph, "\t\n") {
+			return fmt.Errorf("%w %s: %s %q contains a tab or newline", ErrInvalidStyle, name, names[index], glyph)
+		}
+		if glyphWidth := textWidth(glyph); glyphWidth != width {
+			return fmt.Errorf("%w %s: %s %q is %d columns wide, but %s %q is %d", ErrInvalidStyle, name,
+				names[index], glyph, glyphWidth, names[0], glyphs[0], width)
+		}
+	}
+
+	if spec.Color != "" && colorCode(spec.Color) == "" {
+		return fmt.Errorf("%w %s: unknown color %q", ErrInvalidStyle, name, spec.Color)
+	}
+	return nil
+}
+
+// colorCode returns the ANSI SGR code of a color, or "" if it is not a color
+func colorCode(color string) string {
+	if code, ok := colors[strings.ToLower(color)]; ok {
+		return code
+	}
+	for _, r := range color {
+		if (r < '0' || r > '9') && r != ';' {
+			return ""
+		}
+	}
+	return color
+}
+
+// parseStyleSpecs parses a specification in JSON or text
+func parseStyleSpecs(spec []byte) ([]StyleSpec, error) {
+	trimmed := bytes.TrimSpace(spec)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		var specs []StyleSpec
+		if err := json.Unmarshal(trimmed, &specs); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStyle, err)
+		}
+		return specs, nil
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var spec StyleSpec
+		if err := json.Unmarshal(trimmed, &spec); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStyle, err)
+		}
+		return []StyleSpec{spec}, nil
+	}
+
+	var specs []StyleSpec
+	var current *StyleSpec
+	for number, line := range strings.Split(string(spec), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			// a blank line ends the style
+			current = nil
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		}
+		if current == nil {
+			specs = append(specs, StyleSpec{})
+			current = &specs[len(specs)-1]
+		}
+		if err := current.set(line); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidStyle, number+1, err)
+		}
+	}
+	return specs, nil
+}
+
+// set sets the field in a "key: value" line of a text specification
+func (spec *StyleSpec) set(line string) error {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return fmt.Errorf("expected \"key: value\", got %q", line)
+	}
+	key := strings.TrimSpace(line[:colon])
+	values, err := parseValues(strings.TrimSpace(line[colon+1:]))
+	if err != nil {
+		return err
+	}
+
+	if key == "bullets" {
+		spec.Bullets = values
+		return nil
+	}
+	if len(values) != 1 {
+		return fmt.Errorf("%s needs exactly one value", key)
+	}
+	fields := map[string]*string{
+		"name":   &spec.Name,
+		"type":   &spec.Type,
+		"middle": &spec.Middle,
+		"last":   &spec.Last,
+		"bypass": &spec.Bypass,
+		"none":   &spec.None,
+		"indent": &spec.Indent,
+		"color":  &spec.Color,
+	}
+	field, ok := fields[key]
+	if !ok {
+		return fmt.Errorf("unknown key %q", key)
+	}
+	*field = values[0]
+	return nil
+}
+
+// parseValues parses the values of a line of a text specification. Values are either quoted
+// strings, separated by spaces, or a single unquoted value
+func parseValues(s string) ([]string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return []string{s}, nil
+	}
+
+	var values []string
+	for s != "" {
+		if !strings.HasPrefix(s, `"`) {
+			return nil, fmt.Errorf("expected a quoted value at %q", s)
+		}
+		end := 1
+		for end < len(s) && s[end] != '"' {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return nil, fmt.Errorf("unterminated value %s", s)
+		}
+		value, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %s: %v", s[:end+1], err)
+		}
+		values = append(values, value)
+		s = strings.TrimLeft(s[end+1:], " ")
+	}
+	return values, nil
+}
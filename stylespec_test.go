@@ -0,0 +1,139 @@
+package printtree
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadStyles_Text(t *testing.T) {
+	styles, err := LoadStyles([]byte(`
+# arrows for the branches
+name: spec-arrows
+type: structural
+middle: "|>- "
+last: " ` + "`" + `- "
+bypass: "|   "
+none: "    "
+
+name: spec-parens
+type: list
+indent: "    "
+bullets: "(1) " "(a) " "•   "
+`))
+	require.NoError(t, err)
+	require.Len(t, styles, 2)
+	forgetStyleNames(t, styles...)
+
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("one").AddBranches("a", "b")
+	root.AddBranch("two").AddBranch("c").AddBranch("d")
+
+	assert.Equal(t, "spec-arrows", styles[0].String())
+	assert.Equal(t, `root
+|>- one
+|   |>- a
+|    `+"`"+`- b
+ `+"`"+`- two
+     `+"`"+`- c
+         `+"`"+`- d
+`, tree.PrintStyle(styles[0]))
+
+	style, err := StyleByName("spec-parens")
+	assert.NoError(t, err)
+	assert.Equal(t, styles[1], style)
+	assert.Equal(t, `root
+(1) one
+    (a) a
+    (b) b
+(2) two
+    (a) c
+        •   d
+`, tree.PrintStyle(style))
+}
+
+func TestLoadStyles_JSON(t *testing.T) {
+	styles, err := LoadStyles([]byte(`{
+		"type": "structural",
+		"middle": "+- ", "last": "\\- ", "bypass": "|  ", "none": "   ",
+		"color": "red"
+	}`))
+	require.NoError(t, err)
+	require.Len(t, styles, 1)
+
+	tree := NewTree()
+	root := tree.AddBranch("root")
+	root.AddBranch("one").AddBranch("a")
+	root.AddBranch("two")
+
+	// only the scaffold is colored
+	assert.Equal(t, "root\n"+
+		"\x1b[31m+- \x1b[0mone\n"+
+		"\x1b[31m|  \\- \x1b[0ma\n"+
+		"\x1b[31m\\- \x1b[0mtwo\n", tree.PrintStyle(styles[0]))
+	assert.Equal(t, "   root\n"+
+		" one\x1b[31m -+\x1b[0m\n"+
+		"a\x1b[31m -/  |\x1b[0m\n"+
+		" two\x1b[31m -/\x1b[0m\n", tree.PrintStyle(styles[0], WithMirrored()))
+
+	styles, err = LoadStyles([]byte(`[
+		{"type": "list", "indent": "  ", "bullets": ["- "], "color": "38;5;208"},
+		{"type": "list", "indent": "  ", "bullets": ["* "]}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, styles, 2)
+	assert.Equal(t, "\x1b[38;5;208m", scaffoldingDict[styles[0]].color)
+}
+
+func TestLoadStyles_Errors(t *testing.T) {
+	for spec, message := range map[string]string{
+		"type: structural\nmiddle: \"|- \"\nlast: \"'- \"\nbypass: \"|  \"\nnone: \"  \"": `invalid style unnamed: none "  " is 2 columns wide, but middle "|- " is 3`,
+		"name: wide\ntype: list\nindent: \"  \"\nbullets: \"● \" \"✅ \"":                  `invalid style wide: bullet 2 "` + "✅" + ` " is 3 columns wide, but indent "  " is 2`,
+		"type: list\nindent: \"  \"":                               `invalid style unnamed: a list needs at least one bullet`,
+		"type: tree":                                               `invalid style unnamed: unknown type "tree", must be "structural" or "list"`,
+		"type: list\nindent: \"\"\nbullets: \"\"":                  `invalid style unnamed: indent is empty`,
+		"type: list\nindent: \"  \"\nbullets: \"- \"\ncolor: pink": `invalid style unnamed: unknown color "pink"`,
+		"type: list\nindent \"  \"":                                `invalid style: line 2: expected "key: value", got "indent \"  \""`,
+		"type: list\nshape: round":                                 `invalid style: line 2: unknown key "shape"`,
+		"type: list\nindent: \"  ":                                 `invalid style: line 2: unterminated value "`,
+		"type: list\nindent: \"  \" \"  \"":                        `invalid style: line 2: indent needs exactly one value`,
+		`{"type": 1}`:                                              `invalid style: json: cannot unmarshal number into Go struct field StyleSpec.type of type string`,
+		"name: box\ntype: list\nindent: \"  \"\nbullets: \"- \"":   `duplicate style name "box"`,
+	} {
+		_, err := LoadStyles([]byte(spec))
+		assert.EqualError(t, err, message, spec)
+	}
+
+	// nothing is added if any style is invalid
+	count := len(scaffoldingDict)
+	_, err := LoadStyles([]byte("name: spec-first\ntype: list\nindent: \"  \"\nbullets: \"- \"\n\ntype: tree"))
+	assert.True(t, errors.Is(err, ErrInvalidStyle))
+	_, err = LoadStyles([]byte("name: spec-same\ntype: list\nindent: \"  \"\nbullets: \"- \"\n\nname: spec-same\ntype: list\nindent: \"  \"\nbullets: \"- \""))
+	assert.True(t, errors.Is(err, ErrDuplicateStyle))
+	assert.Equal(t, count, len(scaffoldingDict))
+}
+
+func ExampleLoadStyles() {
+	styles, err := LoadStyles([]byte(`
+type: structural
+middle: "+-- "
+last:   "\\-- "
+bypass: "|   "
+none:   "    "
+`))
+	if err != nil {
+		panic(err)
+	}
+
+	tree := NewTree()
+	tree.AddBranch("root").AddBranches("one", "two")
+	fmt.Print(tree.PrintStyle(styles[0]))
+	// Output:
+	// root
+	// +-- one
+	// \-- two
+}
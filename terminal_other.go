@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package printtree
+
+// stdoutColumns returns 0, the size of the terminal can not be asked for on this system
+func stdoutColumns() int {
+	return 0
+}
@@ -0,0 +1,26 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package printtree
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the size of the terminal, as returned by the TIOCGWINSZ ioctl
+type winsize struct {
+	rows, cols, x, y uint16
+}
+
+// stdoutColumns returns the width of the terminal that is the standard output, or 0 if the
+// standard output is not a terminal
+func stdoutColumns() int {
+	var size winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+}
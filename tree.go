@@ -6,11 +6,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 type Tree struct {
-	Label    string // branch name. will be "" in the root node
+	Label    string      // branch name. will be "" in the root node
+	Payload  interface{} // optional data carried by the branch. it is never printed
 	Branches []*Tree
+	// Collapsed branches are printed without their branches. See WithFoldMarkers
+	Collapsed bool
+	// URL is a link (such as a file path or web page) that the label is printed as. See
+	// WithHyperlinks
+	URL string
+	// Icon is printed between the scaffold and the label, like a file type icon. See WithIconFunc
+	Icon   string
+	parent *Tree // the tree this branch was last added to. nil for a root
 }
 
 // BranchLess accepts two branches and returns true if the first branch is less than (comes
@@ -18,11 +28,12 @@ type Tree struct {
 type BranchLess func(branch1, branch2 *Tree) bool
 
 // TreeStyle is the markup style of the tree. It may be one of the `...Style` constants or a
-// higher number if custom styles have been added
+// higher number if custom styles have been added. Styles can also be looked up by their name
+// with StyleByName
 type TreeStyle int
 
 const (
-	ASCIIStyle = iota
+	ASCIIStyle TreeStyle = iota
 	BoxStyle
 	BoxBoldStyle
 	ASCIINarrowStyle
@@ -54,26 +65,50 @@ const (
 )
 
 type scaffolding struct {
-	isList bool     // true if this is a bullet style list
-	markup []string // the markup for different types/levels of branches
+	name   string     // the name of the style, see StyleByName. "" for unnamed custom styles
+	isList bool       // true if this is a bullet style list
+	markup []string   // the markup for different types/levels of branches
+	lines  lineGlyphs // the line drawing glyphs used by the chart layouts
+	color  string     // ANSI escape sequence that the scaffold is printed in. "" for no color
 }
 
+// lineGlyphs are the line drawing characters used by layouts that connect a parent to its
+// branches with lines rather than indentation. Each glyph must be one column wide
+type lineGlyphs struct {
+	horizontal string // ─
+	vertical   string // │
+	topLeft    string // ╭
+	topRight   string // ╮
+	bottomLeft string // ╰
+	teeDown    string // ┬
+	teeUp      string // ┴
+	teeLeft    string // ┤
+	teeRight   string // ├
+	cross      string // ┼
+}
+
+var (
+	asciiLines   = lineGlyphs{"-", "|", "+", "+", "'", "+", "+", "+", "|", "+"}
+	boxLines     = lineGlyphs{"─", "│", "╭", "╮", "╰", "┬", "┴", "┤", "├", "┼"}
+	boxBoldLines = lineGlyphs{"━", "┃", "┏", "┓", "┗", "┳", "┻", "┫", "┣", "╋"}
+)
+
 var scaffoldingDict = []scaffolding{
-	{false, []string{"|-- ", "'-- ", "|   ", "    "}},
-	{false, []string{"├── ", "╰── ", "│   ", "    "}},
-	{false, []string{"┣━━ ", "┗━━ ", "┃   ", "    "}},
-	{false, []string{"|-", "'-", "| ", "  "}},
-	{false, []string{"├ ", "╰ ", "│ ", "  "}},
-	{false, []string{"┣ ", "┗ ", "┃ ", "  "}},
-	{true, []string{"    ", "    "}},
-	{true, []string{"  ", "* ", "+ ", "- "}},
-	{true, []string{"  ", "● ", "○ ", "■ ", "□ "}},
-	{true, []string{"    ", " 1. ", " a. ", " i. ", " A. ", " I. "}},
-	{true, []string{"    ", " 1. "}},
-	{true, []string{"    ", " a. "}},
-	{true, []string{"    ", " A. "}},
-	{true, []string{"      ", "   i. "}},
-	{true, []string{"      ", "   I. "}},
+	{"ascii", false, []string{"|-- ", "'-- ", "|   ", "    "}, asciiLines, ""},
+	{"box", false, []string{"├── ", "╰── ", "│   ", "    "}, boxLines, ""},
+	{"box-bold", false, []string{"┣━━ ", "┗━━ ", "┃   ", "    "}, boxBoldLines, ""},
+	{"ascii-narrow", false, []string{"|-", "'-", "| ", "  "}, asciiLines, ""},
+	{"box-narrow", false, []string{"├ ", "╰ ", "│ ", "  "}, boxLines, ""},
+	{"box-bold-narrow", false, []string{"┣ ", "┗ ", "┃ ", "  "}, boxBoldLines, ""},
+	{"whitespace", true, []string{"    ", "    "}, boxLines, ""},
+	{"ascii-bullet", true, []string{"  ", "* ", "+ ", "- "}, asciiLines, ""},
+	{"bullet", true, []string{"  ", "● ", "○ ", "■ ", "□ "}, boxLines, ""},
+	{"ordered", true, []string{"    ", " 1. ", " a. ", " i. ", " A. ", " I. "}, boxLines, ""},
+	{"number", true, []string{"    ", " 1. "}, boxLines, ""},
+	{"alpha", true, []string{"    ", " a. "}, boxLines, ""},
+	{"alpha-uc", true, []string{"    ", " A. "}, boxLines, ""},
+	{"roman", true, []string{"      ", "   i. "}, boxLines, ""},
+	{"roman-uc", true, []string{"      ", "   I. "}, boxLines, ""},
 }
 
 // NewTree returns a new tree node that has no label. This is the root of a tree that you can
@@ -95,7 +130,8 @@ func NewTree() *Tree {
 // branch
 func (tree *Tree) AddBranch(branchName string) *Tree {
 	childTree := Tree{
-		Label: branchName,
+		Label:  branchName,
+		parent: tree,
 	}
 	tree.Branches = append(tree.Branches, &childTree)
 	return &childTree
@@ -120,26 +156,41 @@ func (tree *Tree) AddBranchf(label string, a ...interface{}) *Tree {
 
 // AddTreeAsBranch grafts in a tree as a branch of this tree. If the other tree has no label,
 // then it is assumed to be a root node, and all it's branches will be added. If it does have a
-// label, then it will be added as a branch
+// label, then it will be added as a branch. The grafted branches become branches of this tree,
+// so Path() and Root() lead through this tree from now on
 //
-// WARNING: It is up to the caller not to create infinite tree loops
+// Grafting a tree into one of its own branches creates a loop. Printing, Depth and DeepSort stop
+// at a branch that loops back to one of its ancestors (it is printed as "↻ (cycle to Label)"),
+// and Validate can be used to find such loops
 func (tree *Tree) AddTreeAsBranch(other *Tree) {
 	if other.Label == "" {
 		// this is a root tree, copy all it's children
 		tree.Branches = append(tree.Branches, other.Branches...)
+		for _, branch := range other.Branches {
+			tree.adopt(branch)
+		}
 	} else {
 		// tree branch, add the branch to this tree
 		tree.Branches = append(tree.Branches, other)
+		tree.adopt(other)
 	}
 }
 
 // Depth returns the maximum depth of the tree. A root tree with no branches is depth 0, a tree
 // with one level of branches has depth 1
 func (tree *Tree) Depth() int {
+	return tree.depth(ancestry{tree: true})
+}
+
+// depth is the internal, recursive hook for Depth
+func (tree *Tree) depth(ancestors ancestry) int {
 	depth := 0
 
 	for index := range tree.Branches {
-		branchDepth := 1 + tree.Branches[index].Depth()
+		branch := ancestors.follow(tree.Branches[index])
+		ancestors[branch] = true
+		branchDepth := 1 + branch.depth(ancestors)
+		delete(ancestors, branch)
 		if branchDepth > depth {
 			depth = branchDepth
 		}
@@ -157,14 +208,12 @@ func (tree *Tree) Sort() {
 
 // DeepSort sorts the children of this tree and all sub-trees by the labels
 func (tree *Tree) DeepSort() {
-	tree.Sort()
-	for index := range tree.Branches {
-		tree.Branches[index].DeepSort()
-	}
+	tree.DeepSortCustom(LabelLess)
 }
 
 // SortCustom sorts the children of this tree by calling a custom function. The less function
-// must return true if child1 comes before child2 in the list
+// must return true if child1 comes before child2 in the list. Ready made functions include
+// NaturalLess, FoldLess, LeavesFirst and SizeLess, which can be combined with Chain and Reverse
 func (tree *Tree) SortCustom(less BranchLess) {
 	sort.SliceStable(tree.Branches, func(i, j int) bool {
 		return less(tree.Branches[i], tree.Branches[j])
@@ -174,9 +223,17 @@ func (tree *Tree) SortCustom(less BranchLess) {
 // DeepSortCustom sorts the children of this tree and all sub-trees by calling a custom function. The
 // less function must return true if child1 comes before child2 in the list
 func (tree *Tree) DeepSortCustom(less BranchLess) {
+	tree.deepSortCustom(less, ancestry{tree: true})
+}
+
+// deepSortCustom is the internal, recursive hook for DeepSortCustom
+func (tree *Tree) deepSortCustom(less BranchLess, ancestors ancestry) {
 	tree.SortCustom(less)
 	for index := range tree.Branches {
-		tree.Branches[index].DeepSortCustom(less)
+		branch := ancestors.follow(tree.Branches[index])
+		ancestors[branch] = true
+		branch.deepSortCustom(less, ancestors)
+		delete(ancestors, branch)
 	}
 }
 
@@ -199,9 +256,11 @@ func (tree *Tree) DeepSortCustom(less BranchLess) {
 //   O    `- Grandchild3
 // The return value will be the value you can pass to `PrintStyle()` to use this style
 func AddStructuralStyle(middleBranch, lastBranch, bypassBranch, noBranch string) TreeStyle {
+	markup := []string{middleBranch, lastBranch, bypassBranch, noBranch}
 	scaffoldingDict = append(scaffoldingDict, scaffolding{
 		isList: false,
-		markup: []string{middleBranch, lastBranch, bypassBranch, noBranch},
+		markup: markup,
+		lines:  linesFor(markup),
 	})
 	return TreeStyle(len(scaffoldingDict) - 1)
 }
@@ -269,9 +328,23 @@ func AddListStyle(indent string, bullets ...string) TreeStyle {
 	})
 	styleIndex := len(scaffoldingDict) - 1
 	scaffoldingDict[styleIndex].markup = append(scaffoldingDict[styleIndex].markup, bullets...)
+	scaffoldingDict[styleIndex].lines = linesFor(scaffoldingDict[styleIndex].markup)
 	return TreeStyle(styleIndex)
 }
 
+// linesFor picks the line drawing glyphs for a custom style. Styles that are made of plain
+// ASCII are drawn with ASCII lines, all others with box drawing lines
+func linesFor(markup []string) lineGlyphs {
+	for _, s := range markup {
+		for _, r := range s {
+			if r > unicode.MaxASCII {
+				return boxLines
+			}
+		}
+	}
+	return asciiLines
+}
+
 // String returns a string representation of this tree indented with whitespace
 func (tree *Tree) String() string {
 	return tree.PrintStyle(WhiteSpaceStyle)
@@ -283,41 +356,81 @@ func (tree *Tree) Print() string {
 }
 
 // PrintStyle returns a string which is this tree printed with custom settings. The TreeStyle
-// indicates what style of markup should be used on the left side of the tree.
-func (tree *Tree) PrintStyle(style TreeStyle) string {
+// indicates what style of markup should be used on the left side of the tree. Options may be
+// added to further control the output, for example
+//   tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(120))
+func (tree *Tree) PrintStyle(style TreeStyle, options ...PrintOption) string {
 	// sanity checks
 	if style < 0 || int(style) >= len(scaffoldingDict) {
 		style = BoxStyle
 	}
 
+	config := newPrintConfig(options)
+	if config.compact {
+		tree = tree.Clone()
+		tree.Compact(config.compactSep, config.compactOptions...)
+	}
 	scaffold := scaffoldingDict[style]
-	buf := strings.Builder{}
-	tree.print(&buf, 0, "", scaffold)
-	return buf.String()
+	switch config.layout {
+	case TopDownLayout, LeftToRightLayout:
+		buf := strings.Builder{}
+		if tree.printChart(&buf, scaffold, config) {
+			return buf.String()
+		}
+		// the chart is too wide, fall back to the indented layout
+	}
+
+	p := &printer{visitor: newVisitor(tree, scaffold, config), config: config, scaffold: scaffold}
+	switch {
+	case config.visibleRoot:
+		root := p.decorate(&Tree{Label: tree.rootLabel(), Collapsed: tree.Collapsed, URL: tree.URL, Icon: tree.Icon, Branches: tree.Branches})
+		if config.inverted {
+			// the branches hang off the root at the bottom
+			root.printInverted(p, 1, "")
+		}
+		for _, line := range strings.Split(root.Label, "\n") {
+			p.addLine(scaffold, "", "", line)
+		}
+		if !config.inverted {
+			root.print(p, 1, "")
+		}
+	case config.inverted:
+		tree.printInverted(p, 0, "")
+	default:
+		tree.print(p, 0, "")
+	}
+	return p.String()
 }
 
-// print is the internal, recursive hook for printing the tree
-func (tree *Tree) print(buf *strings.Builder, depth int, padding string, scaffold scaffolding) {
-	var prefix string // prefix of each line
+// rootLabel returns the label that is printed for this tree when the root is visible. A tree
+// without a label is printed as "." the way the tree command prints the current directory
+func (tree *Tree) rootLabel() string {
+	if tree.Label == "" {
+		return "."
+	}
+	return tree.Label
+}
 
+// print is the internal, recursive hook for printing the tree
+func (tree *Tree) print(p *printer, depth int, padding string) {
 	for index := range tree.Branches {
-		branch := tree.Branches[index]
+		branch := p.enter(tree.Branches[index])
+		scaffold := p.scaffoldFor(branch, depth)
 
 		// handle each line of a block of text separately
 		for lineIndex, line := range strings.Split(branch.Label, "\n") {
 			if lineIndex == 0 {
 				// first (or only) line of a block of text.
-				prefix = padding + tree.labelPadding(depth, index, scaffold)
+				p.addLine(scaffold, padding, tree.labelPadding(depth, index, scaffold), line)
 			} else {
 				// subsequent lines of a block of text. the scaffold is one that indicates that
 				// indicates we are flowing some text
-				prefix = padding + tree.flowPadding(depth, index, scaffold)
+				p.addLine(scaffold, padding, tree.flowPadding(depth, index, scaffold), line)
 			}
-			buf.WriteString(prefix + line + "\n")
 		}
 
-		prefix = padding + tree.flowPadding(depth, index, scaffold)
-		branch.print(buf, depth+1, prefix, scaffold)
+		branch.print(p, depth+1, padding+tree.flowPadding(depth, index, scaffold))
+		p.leave(branch)
 	}
 }
 
@@ -391,9 +504,9 @@ func (tree *Tree) replaceNumberPlaceholder(s string, placeholder string, actualV
 		return s
 	}
 
-	// replace with the actual value, padded to the same length as being replaced. if the actual
-	// value is longer, that is fine and it will just flow to the right
-	actualValue = fmt.Sprintf("%*s", loc[1]-loc[0], actualValue)
+	// replace with the actual value, padded to the same width as being replaced. if the actual
+	// value is wider, that is fine and it will just flow to the right
+	actualValue = padLeft(actualValue, textWidth(s[loc[0]:loc[1]]))
 	return s[:loc[0]] + actualValue + s[loc[1]:]
 }
 
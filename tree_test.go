@@ -760,3 +760,67 @@ func TestDepth(t *testing.T) {
 	assert.Equal(t, 0, branchA.Depth())
 	assert.Equal(t, 1, branchB.Depth())
 }
+
+func TestVisibleRoot(t *testing.T) {
+	tree := NewTree()
+	tree.AddBranch("one").AddBranches("a", "b")
+	tree.AddBranch("two\nlines").AddBranch("c")
+
+	assert.Equal(t, `.
+├── one
+│   ├── a
+│   ╰── b
+╰── two
+    lines
+    ╰── c
+`, tree.PrintStyle(BoxStyle, WithVisibleRoot()))
+
+	assert.Equal(t, `    ╭── a
+    ├── b
+╭── one
+│   ╭── c
+├── two
+│   lines
+.
+`, tree.PrintStyle(BoxStyle, WithVisibleRoot(), WithInverted()))
+
+	assert.Equal(t, `.
+ 1. one
+     1. a
+     2. b
+ 2. two
+    lines
+     1. c
+`, tree.PrintStyle(NumberStyle, WithVisibleRoot()))
+
+	assert.Equal(t, `    .
+ ╭──┴───╮
+one    two
+╭┴─╮  lines
+a  b    │
+        c
+`, tree.PrintStyle(BoxStyle, WithVisibleRoot(), WithLayout(TopDownLayout), WithMaxWidth(0)))
+
+	assert.Equal(t, `   ╭─ one ─┬─ a
+. ─┤       ╰─ b
+   ╰─ two ───── c
+      lines
+`, tree.PrintStyle(BoxStyle, WithVisibleRoot(), WithLayout(LeftToRightLayout), WithMaxWidth(0)))
+}
+
+func TestVisibleRoot_Labeled(t *testing.T) {
+	tree := NewTree()
+	one := tree.AddBranch("one")
+	one.AddBranches("a", "b")
+
+	// a branch prints its own label as the root
+	assert.Equal(t, `one
+|-- a
+'-- b
+`, one.PrintStyle(ASCIIStyle, WithVisibleRoot()))
+
+	// without the option, the label of the branch is not printed
+	assert.Equal(t, `a
+b
+`, one.PrintStyle(ASCIIStyle))
+}
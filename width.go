@@ -0,0 +1,234 @@
+package printtree
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTabWidth is the distance between tab stops when tabs in labels are expanded
+const defaultTabWidth = 8
+
+const (
+	zeroWidthJoiner     = '\u200d'
+	emojiPresentation   = '\ufe0f'
+	regionalIndicatorA  = '\U0001f1e6'
+	regionalIndicatorZ  = '\U0001f1ff'
+	emojiModifierFirst  = '\U0001f3fb'
+	emojiModifierLast   = '\U0001f3ff'
+	hangulJungseongBase = '\u1160'
+	hangulJongseongLast = '\u11ff'
+)
+
+// wideRanges are the ranges of runes that are wide (East Asian Width W or F) and take up two
+// columns in a terminal. The ranges are sorted so they can be binary searched
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec}, {0x23f0, 0x23f0},
+	{0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267f, 0x267f},
+	{0x2693, 0x2693}, {0x26a1, 0x26a1}, {0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5},
+	{0x26ce, 0x26ce}, {0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b}, {0x2728, 0x2728},
+	{0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27b0, 0x27b0}, {0x27bf, 0x27bf}, {0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55},
+	{0x2e80, 0x303e}, {0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe6f},
+	{0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4}, {0x17000, 0x18aff}, {0x1b000, 0x1b2ff},
+	{0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f202},
+	{0x1f210, 0x1f23b}, {0x1f240, 0x1f248}, {0x1f250, 0x1f251}, {0x1f260, 0x1f265}, {0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca}, {0x1f3cf, 0x1f3d3},
+	{0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e}, {0x1f440, 0x1f440}, {0x1f442, 0x1f4fc},
+	{0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e}, {0x1f550, 0x1f567}, {0x1f57a, 0x1f57a}, {0x1f595, 0x1f596},
+	{0x1f5a4, 0x1f5a4}, {0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc}, {0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7}, {0x1f6dc, 0x1f6df}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc}, {0x1f7e0, 0x1f7eb},
+	{0x1f7f0, 0x1f7f0}, {0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945}, {0x1f947, 0x1f9ff}, {0x1fa70, 0x1faff},
+	{0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
+
+// textWidth returns the number of columns that a string occupies when printed in a terminal.
+// Wide characters (CJK, emoji) take two columns, combining marks and other zero width
+// characters take none, and a grapheme cluster (such as an emoji joined with zero width joiners
+// or a flag) is measured as a single character. Tabs advance to the next tab stop and ANSI
+// escape sequences are ignored
+func textWidth(s string) int {
+	width := 0
+	forEachCluster(s, func(cluster string, clusterWidth int) {
+		if cluster == "\t" {
+			width += defaultTabWidth - width%defaultTabWidth
+			return
+		}
+		width += clusterWidth
+	})
+	return width
+}
+
+// expandTabs replaces the tabs in a string with spaces up to the next tab stop. The string is
+// assumed to start at the given column, so tab stops line up with the rest of the output line
+func expandTabs(s string, column int, tabWidth int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	if tabWidth <= 0 {
+		tabWidth = defaultTabWidth
+	}
+
+	buf := strings.Builder{}
+	forEachCluster(s, func(cluster string, width int) {
+		if cluster == "\t" {
+			spaces := tabWidth - column%tabWidth
+			buf.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+			return
+		}
+		buf.WriteString(cluster)
+		column += width
+	})
+	return buf.String()
+}
+
+// splitLabel splits a label into its lines, with the tabs in each line expanded
+func splitLabel(label string, tabWidth int) []string {
+	lines := strings.Split(label, "\n")
+	for index := range lines {
+		lines[index] = expandTabs(lines[index], 0, tabWidth)
+	}
+	return lines
+}
+
+// forEachCluster splits a string into grapheme clusters (the characters a reader would see) and
+// calls fn for each one with the number of columns it takes up. Tabs are passed to fn as
+// separate, zero width clusters
+func forEachCluster(s string, fn func(cluster string, width int)) {
+	for len(s) > 0 {
+		end, width := nextCluster(s)
+		fn(s[:end], width)
+		s = s[end:]
+	}
+}
+
+// nextCluster returns the length in bytes of the grapheme cluster at the start of the string,
+// and the number of columns it takes up
+func nextCluster(s string) (int, int) {
+	if end := escapeLength(s); end > 0 {
+		// escape sequences (such as colors) are not printed
+		return end, 0
+	}
+
+	r, end := utf8.DecodeRuneInString(s)
+	width := runeWidth(r)
+	if r == '\t' {
+		return end, width
+	}
+
+	if r >= regionalIndicatorA && r <= regionalIndicatorZ {
+		// a pair of regional indicators is a flag
+		if next, size := utf8.DecodeRuneInString(s[end:]); next >= regionalIndicatorA && next <= regionalIndicatorZ {
+			end += size
+			width = 2
+		}
+	}
+
+	// consume the runes that extend the cluster
+	for end < len(s) {
+		next, size := utf8.DecodeRuneInString(s[end:])
+		switch {
+		case next == zeroWidthJoiner:
+			// the joiner and the rune that it joins are part of this cluster
+			end += size
+			if end < len(s) {
+				_, size = utf8.DecodeRuneInString(s[end:])
+			} else {
+				size = 0
+			}
+		case next == emojiPresentation:
+			// the preceding character is displayed as a (wide) emoji
+			width = 2
+		case !isExtender(next):
+			return end, width
+		}
+		end += size
+	}
+	return end, width
+}
+
+// escapeLength returns the length in bytes of the ANSI control sequence (such as a color) or
+// operating system command (such as a hyperlink) at the start of the string, or 0 if the string
+// does not start with one
+func escapeLength(s string) int {
+	if strings.HasPrefix(s, "\x1b]") {
+		// commands end with BEL or ST (ESC \)
+		for end := 2; end < len(s); end++ {
+			switch {
+			case s[end] == '\a':
+				return end + 1
+			case s[end] == '\x1b' && end+1 < len(s) && s[end+1] == '\\':
+				return end + 2
+			}
+		}
+		return len(s)
+	}
+	if !strings.HasPrefix(s, "\x1b[") {
+		return 0
+	}
+	for end := 2; end < len(s); end++ {
+		if s[end] >= 0x40 && s[end] <= 0x7e {
+			// final byte of the sequence
+			return end + 1
+		}
+	}
+	return len(s)
+}
+
+// isExtender returns true if the rune extends the grapheme cluster in front of it without
+// taking up any columns of its own
+func isExtender(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Variation_Selector) ||
+		(r >= emojiModifierFirst && r <= emojiModifierLast) ||
+		(r >= hangulJungseongBase && r <= hangulJongseongLast)
+}
+
+// runeWidth returns the number of columns a single rune takes up when printed by itself
+func runeWidth(r rune) int {
+	switch {
+	case r == '\t':
+		return 0
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		// control characters
+		return 0
+	case r < 0x1100:
+		// fast path for the common (narrow) case
+		if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+			return 0
+		}
+		return 1
+	case isExtender(r) || unicode.Is(unicode.Cf, r):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide returns true if the rune is an East Asian wide or full width character
+func isWide(r rune) bool {
+	index := sort.Search(len(wideRanges), func(i int) bool {
+		return wideRanges[i][1] >= r
+	})
+	return index < len(wideRanges) && wideRanges[index][0] <= r
+}
+
+// padRight pads a string with spaces on the right so that it is at least width wide
+func padRight(s string, width int) string {
+	if pad := width - textWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// padLeft pads a string with spaces on the left so that it is at least width wide
+func padLeft(s string, width int) string {
+	if pad := width - textWidth(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
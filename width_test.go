@@ -0,0 +1,106 @@
+package printtree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextWidth(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"├── ", 4},
+		{"日本語", 6},
+		{"ｈｅｌｌｏ", 10},
+		{"한국어", 6},
+		{"e\u0301te\u0301", 3},      // combining acute accents
+		{"\U0001f333", 2},           // emoji
+		{"\U0001f44d\U0001f3fd", 2}, // emoji with skin tone modifier
+		{"\U0001f468\u200d\U0001f469\u200d\U0001f467", 2}, // family joined with zero width joiners
+		{"\U0001f1f3\U0001f1ff", 2},                       // flag from regional indicators
+		{"\u2764\ufe0f", 2},                               // emoji presentation selector
+		{"a\u200bb", 2},                                   // zero width space
+		{"\x1b", 0},                                       // control character
+		{"a\tb", 9},                                       // tab to the next tab stop
+		{"\t", 8},                                         // tab from the start of the line
+		{"日本\t語", 10},                                     // tab after wide characters
+		{"\u1100\u1161\u11a8", 2},                         // conjoining hangul jamo
+		{"\x1b[31mred\x1b[0m", 3},                         // ANSI colors
+	}
+
+	for index, tc := range cases {
+		assert.Equal(t, tc.expected, textWidth(tc.s), "test case %d (%q) failed", index, tc.s)
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	assert.Equal(t, "no tabs", expandTabs("no tabs", 0, 8))
+	assert.Equal(t, "a       b", expandTabs("a\tb", 0, 8))
+	assert.Equal(t, "a   b", expandTabs("a\tb", 0, 4))
+	assert.Equal(t, "a b", expandTabs("a\tb", 2, 4))
+	assert.Equal(t, "日本    語", expandTabs("日本\t語", 0, 8))
+	assert.Equal(t, "        ", expandTabs("\t", 0, 0))
+}
+
+func TestForEachCluster(t *testing.T) {
+	var clusters []string
+	var widths []int
+	forEachCluster("ae\u0301\U0001f1f3\U0001f1ff\t\U0001f468\u200d\U0001f469", func(cluster string, width int) {
+		clusters = append(clusters, cluster)
+		widths = append(widths, width)
+	})
+	assert.Equal(t, []string{"a", "e\u0301", "\U0001f1f3\U0001f1ff", "\t", "\U0001f468\u200d\U0001f469"}, clusters)
+	assert.Equal(t, []int{1, 1, 2, 0, 2}, widths)
+}
+
+func TestWideLabels(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("家族")
+	root.AddBranches("母", "父\t(父親)")
+
+	// labels are centered by width, not by the number of runes
+	result := tree.PrintStyle(BoxStyle, WithLayout(TopDownLayout), WithMaxWidth(0))
+	assert.Equal(t, `    家族
+╭────┴────╮
+母  父      (父親)
+`, result)
+
+	// labels are right aligned by width
+	result = tree.PrintStyle(BoxStyle, WithMirrored(), WithTabWidth(4))
+	assert.Equal(t, `          家族
+        母 ──┤
+父  (父親) ──╯
+`, result)
+
+	// tabs line up with the tab stops of the whole line
+	result = tree.PrintStyle(ASCIIStyle, WithTabWidth(4))
+	assert.Equal(t, `家族
+|-- 母
+'-- 父  (父親)
+`, result)
+}
+
+func TestWideStructuralStyle(t *testing.T) {
+	tree := NewTree()
+	root := tree.AddBranch("Root")
+	root.AddBranch("a").AddBranch("i")
+	root.AddBranch("b")
+
+	style := AddStructuralStyle("🌿 ", "🍂 ", "|  ", "   ")
+	result := tree.PrintStyle(style)
+	assert.Equal(t, `Root
+🌿 a
+|  🍂 i
+🍂 b
+`, result)
+}
+
+func TestReplaceNumberPlaceholder_Wide(t *testing.T) {
+	tree := NewTree()
+	assert.Equal(t, "  一", tree.replaceNumberPlaceholder("   1", "1", "一"))
+	assert.Equal(t, "一二三", tree.replaceNumberPlaceholder("  1", "1", "一二三"))
+}